@@ -0,0 +1,225 @@
+package tarantool
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+)
+
+// ArrayStruct is utility type for encoding a struct into an array of field
+// values and decoding it back. Fields are encoded in the order of
+// declaration, fields with the "-" tag and unexported fields are skipped.
+// It allows to pass a struct as positional arguments of a function:
+//
+//	req := NewCallRequest("func").Args(ArrayStruct{&args})
+//
+// A position of a field in the array could be set with the "tarantool" tag.
+// If any field of a struct has the tag, only tagged fields are used, absent
+// positions are encoded as nil and skipped on decoding. It allows to decode
+// a few non-contiguous fields of a tuple:
+//
+//	type User struct {
+//		Name string `tarantool:"3"`
+//		Id   uint   `tarantool:"0"`
+//	}
+//
+//	var users []User
+//	err := conn.SelectTyped(space, index, 0, 10, IterAll, key,
+//		&ArrayStruct{&users})
+//
+// V must be a struct or a pointer to a struct for encoding and a pointer
+// to a struct for decoding. A pointer to a slice of structs or pointers to
+// structs could be used for decoding too, each item of an array is decoded
+// as an array of field values in the case. Extra array items are skipped on
+// decoding.
+type ArrayStruct struct {
+	V interface{}
+}
+
+// arrayStructField is a struct field with a position in the array.
+type arrayStructField struct {
+	pos   int
+	index int
+}
+
+// arrayStructFields is a cache of struct type -> []arrayStructField.
+var arrayStructFields sync.Map
+
+// EncodeMsgpack encodes the struct as an array of field values.
+func (s ArrayStruct) EncodeMsgpack(enc *encoder) error {
+	val := reflect.ValueOf(s.V)
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return enc.EncodeNil()
+		}
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return fmt.Errorf("ArrayStruct: unsupported type %T", s.V)
+	}
+
+	fields, err := getArrayStructFields(val.Type())
+	if err != nil {
+		return err
+	}
+
+	l := 0
+	if len(fields) > 0 {
+		l = fields[len(fields)-1].pos + 1
+	}
+	if err := enc.EncodeArrayLen(l); err != nil {
+		return err
+	}
+	pos := 0
+	for _, field := range fields {
+		for ; pos < field.pos; pos++ {
+			if err := enc.EncodeNil(); err != nil {
+				return err
+			}
+		}
+		if err := enc.EncodeValue(val.Field(field.index)); err != nil {
+			return err
+		}
+		pos++
+	}
+	return nil
+}
+
+// DecodeMsgpack decodes an array of field values into the struct or
+// an array of such arrays into the slice.
+func (s *ArrayStruct) DecodeMsgpack(d *decoder) error {
+	val := reflect.ValueOf(s.V)
+	if val.Kind() != reflect.Ptr || val.IsNil() {
+		return fmt.Errorf("ArrayStruct: unsupported type %T, a pointer to "+
+			"a struct or a slice expected", s.V)
+	}
+	val = val.Elem()
+
+	switch val.Kind() {
+	case reflect.Struct:
+		return decodeArrayStruct(d, val)
+	case reflect.Slice:
+		return decodeArrayStructSlice(d, val)
+	}
+	return fmt.Errorf("ArrayStruct: unsupported type %T, a pointer to "+
+		"a struct or a slice expected", s.V)
+}
+
+func decodeArrayStructSlice(d *decoder, val reflect.Value) error {
+	elemType := val.Type().Elem()
+	structType := elemType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return fmt.Errorf("ArrayStruct: unsupported slice item type %s, "+
+			"a struct or a pointer to a struct expected", elemType)
+	}
+
+	l, err := d.DecodeArrayLen()
+	if err != nil {
+		return err
+	}
+	if l < 0 {
+		val.Set(reflect.Zero(val.Type()))
+		return nil
+	}
+
+	slice := reflect.MakeSlice(val.Type(), l, l)
+	for i := 0; i < l; i++ {
+		item := slice.Index(i)
+		if elemType.Kind() == reflect.Ptr {
+			item.Set(reflect.New(structType))
+			item = item.Elem()
+		}
+		if err := decodeArrayStruct(d, item); err != nil {
+			return err
+		}
+	}
+	val.Set(slice)
+	return nil
+}
+
+func decodeArrayStruct(d *decoder, val reflect.Value) error {
+	l, err := d.DecodeArrayLen()
+	if err != nil {
+		return err
+	}
+
+	fields, err := getArrayStructFields(val.Type())
+	if err != nil {
+		return err
+	}
+
+	next := 0
+	for i := 0; i < l; i++ {
+		if next < len(fields) && fields[next].pos == i {
+			err = d.DecodeValue(val.Field(fields[next].index))
+			next++
+		} else {
+			err = d.Skip()
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getArrayStructFields returns fields of the struct type sorted by
+// positions.
+func getArrayStructFields(typ reflect.Type) ([]arrayStructField, error) {
+	if cached, ok := arrayStructFields.Load(typ); ok {
+		return cached.([]arrayStructField), nil
+	}
+
+	tagged := false
+	for i := 0; i < typ.NumField(); i++ {
+		if _, ok := typ.Field(i).Tag.Lookup("tarantool"); ok {
+			tagged = true
+			break
+		}
+	}
+
+	var fields []arrayStructField
+	if tagged {
+		byPos := make(map[int]int)
+		maxPos := -1
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			tag, ok := field.Tag.Lookup("tarantool")
+			if !ok || field.PkgPath != "" {
+				continue
+			}
+			pos, err := strconv.Atoi(tag)
+			if err != nil || pos < 0 {
+				return nil, fmt.Errorf("ArrayStruct: invalid position %q "+
+					"of the field %s.%s", tag, typ, field.Name)
+			}
+			if _, ok := byPos[pos]; ok {
+				return nil, fmt.Errorf("ArrayStruct: duplicate position %d "+
+					"of the field %s.%s", pos, typ, field.Name)
+			}
+			byPos[pos] = i
+			if pos > maxPos {
+				maxPos = pos
+			}
+		}
+		fields = make([]arrayStructField, 0, len(byPos))
+		for pos := 0; pos <= maxPos; pos++ {
+			if index, ok := byPos[pos]; ok {
+				fields = append(fields, arrayStructField{pos: pos, index: index})
+			}
+		}
+	} else {
+		snakeFields := getSnakeCaseFields(typ)
+		fields = make([]arrayStructField, len(snakeFields))
+		for i, field := range snakeFields {
+			fields[i] = arrayStructField{pos: i, index: field.index}
+		}
+	}
+
+	arrayStructFields.Store(typ, fields)
+	return fields, nil
+}
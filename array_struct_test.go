@@ -0,0 +1,125 @@
+package tarantool_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/tarantool/go-tarantool"
+)
+
+type arrayStructArgs struct {
+	Id         uint
+	Name       string
+	Skipped    string `msgpack:"-"`
+	unexported string
+}
+
+func TestArrayStruct(t *testing.T) {
+	args := arrayStructArgs{
+		Id:      1,
+		Name:    "name",
+		Skipped: "skipped",
+	}
+
+	data, err := marshal(ArrayStruct{&args})
+	require.Nil(t, err)
+
+	var arr []interface{}
+	err = unmarshal(data, &arr)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(arr))
+	require.Equal(t, "name", arr[1])
+
+	var decoded arrayStructArgs
+	err = unmarshal(data, &ArrayStruct{&decoded})
+	require.Nil(t, err)
+	require.Equal(t, arrayStructArgs{Id: 1, Name: "name"}, decoded)
+}
+
+func TestArrayStruct_extraItems(t *testing.T) {
+	data, err := marshal([]interface{}{1, "name", "extra"})
+	require.Nil(t, err)
+
+	var decoded arrayStructArgs
+	err = unmarshal(data, &ArrayStruct{&decoded})
+	require.Nil(t, err)
+	require.Equal(t, arrayStructArgs{Id: 1, Name: "name"}, decoded)
+}
+
+func TestArrayStruct_unsupported(t *testing.T) {
+	_, err := marshal(ArrayStruct{1})
+	require.NotNil(t, err)
+
+	var decoded arrayStructArgs
+	data, err := marshal([]interface{}{1})
+	require.Nil(t, err)
+	err = unmarshal(data, &ArrayStruct{decoded})
+	require.NotNil(t, err)
+}
+
+type arrayStructPositions struct {
+	Name    string `tarantool:"3"`
+	Id      uint   `tarantool:"0"`
+	Skipped string
+}
+
+func TestArrayStruct_positions(t *testing.T) {
+	data, err := marshal(ArrayStruct{arrayStructPositions{Id: 1, Name: "name"}})
+	require.Nil(t, err)
+
+	var arr []interface{}
+	err = unmarshal(data, &arr)
+	require.Nil(t, err)
+	require.Equal(t, 4, len(arr))
+	require.Nil(t, arr[1])
+	require.Nil(t, arr[2])
+	require.Equal(t, "name", arr[3])
+
+	data, err = marshal([]interface{}{1, "a", "b", "name", "c"})
+	require.Nil(t, err)
+
+	var decoded arrayStructPositions
+	err = unmarshal(data, &ArrayStruct{&decoded})
+	require.Nil(t, err)
+	require.Equal(t, arrayStructPositions{Id: 1, Name: "name"}, decoded)
+}
+
+func TestArrayStruct_slice(t *testing.T) {
+	data, err := marshal([]interface{}{
+		[]interface{}{1, "a", "b", "first"},
+		[]interface{}{2, "a", "b", "second"},
+	})
+	require.Nil(t, err)
+
+	var decoded []arrayStructPositions
+	err = unmarshal(data, &ArrayStruct{&decoded})
+	require.Nil(t, err)
+	require.Equal(t, []arrayStructPositions{
+		{Id: 1, Name: "first"},
+		{Id: 2, Name: "second"},
+	}, decoded)
+
+	var pointers []*arrayStructPositions
+	err = unmarshal(data, &ArrayStruct{&pointers})
+	require.Nil(t, err)
+	require.Equal(t, []*arrayStructPositions{
+		{Id: 1, Name: "first"},
+		{Id: 2, Name: "second"},
+	}, pointers)
+}
+
+func TestArrayStruct_invalidPosition(t *testing.T) {
+	type invalid struct {
+		Id uint `tarantool:"first"`
+	}
+	_, err := marshal(ArrayStruct{invalid{}})
+	require.NotNil(t, err)
+
+	type duplicate struct {
+		Id   uint   `tarantool:"1"`
+		Name string `tarantool:"1"`
+	}
+	_, err = marshal(ArrayStruct{duplicate{}})
+	require.NotNil(t, err)
+}
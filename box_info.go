@@ -0,0 +1,255 @@
+package tarantool
+
+import (
+	"fmt"
+)
+
+// BoxInfo contains information about a Tarantool instance from box.info.
+type BoxInfo struct {
+	// Version is a Tarantool version.
+	Version string
+	// Id is a replica id of the instance.
+	Id uint64
+	// UUID is an instance UUID.
+	UUID string
+	// RO is true if the instance is in read-only mode.
+	RO bool
+	// Status is an instance status, "running" for a configured instance.
+	Status string
+	// LSN is a log sequence number of the instance.
+	LSN uint64
+	// VClock is a map from replica ids to log sequence numbers.
+	VClock map[uint64]uint64
+	// Replication is a map from replica ids to replication information.
+	Replication map[uint64]ReplicaInfo
+}
+
+// ReplicaInfo contains replication information about a replica from
+// box.info.replication.
+type ReplicaInfo struct {
+	// Id is a replica id.
+	Id uint64
+	// UUID is a replica UUID.
+	UUID string
+	// LSN is a log sequence number of the replica.
+	LSN uint64
+	// Upstream contains information about replication from the replica,
+	// it is nil if there is no upstream.
+	Upstream *ReplicaStreamInfo
+	// Downstream contains information about replication to the replica,
+	// it is nil if there is no downstream.
+	Downstream *ReplicaStreamInfo
+}
+
+// ReplicaStreamInfo contains information about an upstream or a downstream
+// of a replica.
+type ReplicaStreamInfo struct {
+	// Status is a replication status: "follow", "stopped" and so on.
+	Status string
+	// Lag is a replication lag in seconds.
+	Lag float64
+	// Idle is a time in seconds since the last event.
+	Idle float64
+	// Message contains an error message, if any.
+	Message string
+}
+
+// DecodeMsgpack decodes box.info.
+func (info *BoxInfo) DecodeMsgpack(d *decoder) error {
+	l, err := d.DecodeMapLen()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < l; i++ {
+		key, err := d.DecodeString()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "version":
+			info.Version, err = d.DecodeString()
+		case "id":
+			info.Id, err = d.DecodeUint64()
+		case "uuid":
+			info.UUID, err = d.DecodeString()
+		case "ro":
+			info.RO, err = d.DecodeBool()
+		case "status":
+			info.Status, err = d.DecodeString()
+		case "lsn":
+			info.LSN, err = d.DecodeUint64()
+		case "vclock":
+			info.VClock = make(map[uint64]uint64)
+			err = decodeReplicaMap(d, func(id uint64) error {
+				lsn, err := d.DecodeUint64()
+				info.VClock[id] = lsn
+				return err
+			})
+		case "replication":
+			info.Replication = make(map[uint64]ReplicaInfo)
+			err = decodeReplicaMap(d, func(id uint64) error {
+				var replica ReplicaInfo
+				err := replica.DecodeMsgpack(d)
+				info.Replication[id] = replica
+				return err
+			})
+		default:
+			err = d.Skip()
+		}
+		if err != nil {
+			return fmt.Errorf("failed to decode box.info.%s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// DecodeMsgpack decodes an item of box.info.replication.
+func (info *ReplicaInfo) DecodeMsgpack(d *decoder) error {
+	l, err := d.DecodeMapLen()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < l; i++ {
+		key, err := d.DecodeString()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "id":
+			info.Id, err = d.DecodeUint64()
+		case "uuid":
+			info.UUID, err = d.DecodeString()
+		case "lsn":
+			info.LSN, err = d.DecodeUint64()
+		case "upstream":
+			info.Upstream, err = decodeReplicaStreamInfo(d)
+		case "downstream":
+			info.Downstream, err = decodeReplicaStreamInfo(d)
+		default:
+			err = d.Skip()
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecodeMsgpack decodes an upstream or a downstream of a replica.
+func (info *ReplicaStreamInfo) DecodeMsgpack(d *decoder) error {
+	l, err := d.DecodeMapLen()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < l; i++ {
+		key, err := d.DecodeString()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "status":
+			info.Status, err = d.DecodeString()
+		case "lag":
+			info.Lag, err = decodeNumber(d)
+		case "idle":
+			info.Idle, err = decodeNumber(d)
+		case "message":
+			info.Message, err = d.DecodeString()
+		default:
+			err = d.Skip()
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decodeReplicaStreamInfo(d *decoder) (*ReplicaStreamInfo, error) {
+	code, err := d.PeekCode()
+	if err != nil {
+		return nil, err
+	}
+	if msgpackIsNil(code) {
+		return nil, d.Skip()
+	}
+	info := new(ReplicaStreamInfo)
+	return info, info.DecodeMsgpack(d)
+}
+
+// decodeReplicaMap decodes a Lua table with replica id keys. Tarantool
+// encodes such table as an array if the keys are sequential.
+func decodeReplicaMap(d *decoder, decodeValue func(id uint64) error) error {
+	code, err := d.PeekCode()
+	if err != nil {
+		return err
+	}
+
+	if msgpackIsArray(code) {
+		l, err := d.DecodeArrayLen()
+		if err != nil {
+			return err
+		}
+		for i := 0; i < l; i++ {
+			if code, err = d.PeekCode(); err != nil {
+				return err
+			}
+			if msgpackIsNil(code) {
+				err = d.Skip()
+			} else {
+				err = decodeValue(uint64(i + 1))
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	l, err := d.DecodeMapLen()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < l; i++ {
+		id, err := d.DecodeUint64()
+		if err != nil {
+			return err
+		}
+		if err = decodeValue(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeNumber decodes an integer or a floating-point number as float64.
+// Tarantool encodes integral Lua numbers as integers.
+func decodeNumber(d *decoder) (float64, error) {
+	value, err := d.DecodeInterface()
+	if err != nil {
+		return 0, err
+	}
+	switch value := value.(type) {
+	case float64:
+		return value, nil
+	case float32:
+		return float64(value), nil
+	case int64:
+		return float64(value), nil
+	case int32:
+		return float64(value), nil
+	case int16:
+		return float64(value), nil
+	case int8:
+		return float64(value), nil
+	case uint64:
+		return float64(value), nil
+	case uint32:
+		return float64(value), nil
+	case uint16:
+		return float64(value), nil
+	case uint8:
+		return float64(value), nil
+	}
+	return 0, fmt.Errorf("unexpected number type %T", value)
+}
@@ -0,0 +1,70 @@
+package tarantool_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/tarantool/go-tarantool"
+)
+
+func TestBoxInfo_decode(t *testing.T) {
+	data, err := marshal(map[string]interface{}{
+		"version": "2.11.1-0-g96877bd",
+		"id":      1,
+		"uuid":    "a1d7b3e5-ebb0-4b1c-9d9c-2f6f3ea3c7b1",
+		"ro":      true,
+		"status":  "running",
+		"lsn":     10,
+		"vclock":  []interface{}{10, nil, 3},
+		"replication": map[uint]interface{}{
+			1: map[string]interface{}{
+				"id":   1,
+				"uuid": "a1d7b3e5-ebb0-4b1c-9d9c-2f6f3ea3c7b1",
+				"lsn":  10,
+			},
+			3: map[string]interface{}{
+				"id":   3,
+				"uuid": "c0d1b3e5-ebb0-4b1c-9d9c-2f6f3ea3c7b1",
+				"lsn":  3,
+				"upstream": map[string]interface{}{
+					"status": "follow",
+					"lag":    0.5,
+					"idle":   2,
+				},
+			},
+		},
+		"unknown": "skipped",
+	})
+	require.Nil(t, err)
+
+	var info BoxInfo
+	err = unmarshal(data, &info)
+	require.Nil(t, err)
+	require.Equal(t, BoxInfo{
+		Version: "2.11.1-0-g96877bd",
+		Id:      1,
+		UUID:    "a1d7b3e5-ebb0-4b1c-9d9c-2f6f3ea3c7b1",
+		RO:      true,
+		Status:  "running",
+		LSN:     10,
+		VClock:  map[uint64]uint64{1: 10, 3: 3},
+		Replication: map[uint64]ReplicaInfo{
+			1: {
+				Id:   1,
+				UUID: "a1d7b3e5-ebb0-4b1c-9d9c-2f6f3ea3c7b1",
+				LSN:  10,
+			},
+			3: {
+				Id:   3,
+				UUID: "c0d1b3e5-ebb0-4b1c-9d9c-2f6f3ea3c7b1",
+				LSN:  3,
+				Upstream: &ReplicaStreamInfo{
+					Status: "follow",
+					Lag:    0.5,
+					Idle:   2,
+				},
+			},
+		},
+	}, info)
+}
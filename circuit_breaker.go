@@ -0,0 +1,118 @@
+package tarantool
+
+import (
+	"sync"
+	"time"
+)
+
+// CircuitBreaker configures a circuit breaker of a connection. The circuit
+// opens after Threshold consecutive failed requests and requests fail fast
+// with ErrCircuitOpen error while it is open. After OpenDuration a single
+// probe request is sent: the circuit closes if it succeeds and opens again
+// otherwise.
+//
+// A request is failed if it finishes with a ClientError: a timeout,
+// a connection error and so on. A request is succeeded if it finishes
+// without an error or with an error returned by Tarantool.
+type CircuitBreaker struct {
+	// Threshold is a number of consecutive failed requests to open the
+	// circuit. The circuit breaker is disabled if it is zero.
+	Threshold uint
+	// OpenDuration is a time to fail requests fast before a probe request.
+	OpenDuration time.Duration
+}
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// circuitBreaker is a thread-safe state of a CircuitBreaker.
+type circuitBreaker struct {
+	mutex    sync.Mutex
+	opts     CircuitBreaker
+	state    circuitState
+	failures uint
+	openedAt time.Time
+}
+
+func newCircuitBreaker(opts CircuitBreaker) *circuitBreaker {
+	return &circuitBreaker{
+		opts: opts,
+	}
+}
+
+// allow returns true if a request could be sent. It allows a single probe
+// request after OpenDuration since the circuit opening.
+func (breaker *circuitBreaker) allow() bool {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	switch breaker.state {
+	case circuitOpen:
+		if time.Since(breaker.openedAt) < breaker.opts.OpenDuration {
+			return false
+		}
+		breaker.state = circuitHalfOpen
+		return true
+	case circuitHalfOpen:
+		return false
+	}
+	return true
+}
+
+// report updates the state with a result of a request.
+func (breaker *circuitBreaker) report(err error) {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	switch err := err.(type) {
+	case nil, Error:
+		breaker.state = circuitClosed
+		breaker.failures = 0
+		return
+	case ClientError:
+		if err.Code != ErrRateLimited && err.Code != ErrRequestTooLarge {
+			break
+		}
+		breaker.release()
+		return
+	default:
+		// The error does not say anything about the instance: a context
+		// cancel, an encoding error and so on.
+		breaker.release()
+		return
+	}
+
+	breaker.failures++
+	if breaker.state == circuitHalfOpen ||
+		breaker.failures >= breaker.opts.Threshold {
+		breaker.state = circuitOpen
+		breaker.openedAt = time.Now()
+	}
+}
+
+// release allows a next probe if a probe is finished with an error that
+// does not say anything about the instance.
+func (breaker *circuitBreaker) release() {
+	if breaker.state == circuitHalfOpen {
+		breaker.state = circuitOpen
+	}
+}
+
+// isOpen returns true if requests fail fast at the moment.
+func (breaker *circuitBreaker) isOpen() bool {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	switch breaker.state {
+	case circuitOpen:
+		return time.Since(breaker.openedAt) < breaker.opts.OpenDuration
+	case circuitHalfOpen:
+		return true
+	}
+	return false
+}
@@ -1,5 +1,10 @@
 package tarantool
 
+import (
+	"encoding/binary"
+	"fmt"
+)
+
 // IntKey is utility type for passing integer key to Select*, Update*,
 // Delete* and GetTyped. It serializes to array with single integer element.
 type IntKey struct {
@@ -50,6 +55,64 @@ func (k IntIntKey) EncodeMsgpack(enc *encoder) error {
 	return nil
 }
 
+// BinaryKey is utility type for passing binary key to Select*, Update*,
+// Delete* and GetTyped. It serializes to array with single binary
+// (MP_BIN) element, so it could be used with varbinary indexes.
+type BinaryKey struct {
+	B []byte
+}
+
+func (k BinaryKey) EncodeMsgpack(enc *encoder) error {
+	enc.EncodeArrayLen(1)
+	return enc.EncodeBytes(k.B)
+}
+
+// Uint64BinaryKey returns a BinaryKey with the big-endian encoded value.
+// See EncodeUint64BE.
+func Uint64BinaryKey(v uint64) BinaryKey {
+	return BinaryKey{EncodeUint64BE(v)}
+}
+
+// Int64BinaryKey returns a BinaryKey with the big-endian encoded value.
+// See EncodeInt64BE.
+func Int64BinaryKey(v int64) BinaryKey {
+	return BinaryKey{EncodeInt64BE(v)}
+}
+
+// EncodeUint64BE encodes the value into 8 bytes in big-endian byte order.
+// The lexicographic order of the encoded values is the same as the numeric
+// order of the values, so they could be stored in a varbinary index.
+func EncodeUint64BE(v uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, v)
+	return b
+}
+
+// DecodeUint64BE decodes a value encoded with EncodeUint64BE.
+func DecodeUint64BE(b []byte) (uint64, error) {
+	if len(b) != 8 {
+		return 0, fmt.Errorf("wrong binary key length %d, expected 8", len(b))
+	}
+	return binary.BigEndian.Uint64(b), nil
+}
+
+// EncodeInt64BE encodes the value into 8 bytes in big-endian byte order
+// with the sign bit flipped. The lexicographic order of the encoded values
+// is the same as the numeric order of the values, negative values are
+// less than positive ones.
+func EncodeInt64BE(v int64) []byte {
+	return EncodeUint64BE(uint64(v) ^ (1 << 63))
+}
+
+// DecodeInt64BE decodes a value encoded with EncodeInt64BE.
+func DecodeInt64BE(b []byte) (int64, error) {
+	v, err := DecodeUint64BE(b)
+	if err != nil {
+		return 0, err
+	}
+	return int64(v ^ (1 << 63)), nil
+}
+
 // Op - is update operation.
 type Op struct {
 	Op    string
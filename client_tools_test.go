@@ -0,0 +1,61 @@
+package tarantool_test
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/tarantool/go-tarantool"
+)
+
+func TestUint64BE(t *testing.T) {
+	values := []uint64{0, 1, 255, 256, math.MaxUint32, math.MaxUint64 - 1, math.MaxUint64}
+
+	for i, v := range values {
+		encoded := EncodeUint64BE(v)
+		require.Equal(t, 8, len(encoded))
+
+		decoded, err := DecodeUint64BE(encoded)
+		require.Nil(t, err)
+		require.Equal(t, v, decoded)
+
+		if i > 0 {
+			prev := EncodeUint64BE(values[i-1])
+			require.Equal(t, -1, bytes.Compare(prev, encoded))
+		}
+	}
+}
+
+func TestInt64BE(t *testing.T) {
+	values := []int64{math.MinInt64, math.MinInt32, -256, -1, 0, 1, 256, math.MaxInt64}
+
+	for i, v := range values {
+		encoded := EncodeInt64BE(v)
+		require.Equal(t, 8, len(encoded))
+
+		decoded, err := DecodeInt64BE(encoded)
+		require.Nil(t, err)
+		require.Equal(t, v, decoded)
+
+		if i > 0 {
+			prev := EncodeInt64BE(values[i-1])
+			require.Equal(t, -1, bytes.Compare(prev, encoded))
+		}
+	}
+}
+
+func TestDecodeUint64BE_WrongLength(t *testing.T) {
+	_, err := DecodeUint64BE([]byte{1, 2, 3})
+	require.NotNil(t, err)
+
+	v, err := DecodeInt64BE([]byte{})
+	require.NotNil(t, err)
+	require.Equal(t, int64(0), v)
+}
+
+func TestBinaryKey(t *testing.T) {
+	require.Equal(t, BinaryKey{EncodeUint64BE(42)}, Uint64BinaryKey(42))
+	require.Equal(t, BinaryKey{EncodeInt64BE(-42)}, Int64BinaryKey(-42))
+}
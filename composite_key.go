@@ -0,0 +1,42 @@
+package tarantool
+
+import (
+	"fmt"
+)
+
+// CompositeKey is a full key of a multipart index. It is encoded as an
+// array of the parts.
+//
+// SelectRequest validates the number of parts against the index if the
+// schema is loaded, so a partial key passed by mistake is reported as an
+// error instead of a partial key match. Use a slice for partial keys.
+type CompositeKey []interface{}
+
+// Key returns a CompositeKey with the parts.
+func Key(parts ...interface{}) CompositeKey {
+	return CompositeKey(parts)
+}
+
+// validate checks that the key contains all parts of the index. It skips
+// the check if the index could not be found in the schema of the resolver.
+func (key CompositeKey) validate(res SchemaResolver, space,
+	index interface{}) error {
+	schema := resolverSchema(res)
+	if schema == nil {
+		return nil
+	}
+
+	s, err := schema.Space(space)
+	if err != nil {
+		return nil
+	}
+	i, err := s.Index(index)
+	if err != nil {
+		return nil
+	}
+	if len(key) != len(i.Fields) {
+		return fmt.Errorf("invalid key: %d parts, index %s of space %s "+
+			"has %d parts", len(key), i.Name, s.Name, len(i.Fields))
+	}
+	return nil
+}
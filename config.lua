@@ -153,6 +153,9 @@ box.once("init", function()
     box.schema.user.grant('test', 'create,read,write,drop,alter', 'space')
     box.schema.user.grant('test', 'create', 'sequence')
 
+    box.schema.sequence.create('test_seq', {if_not_exists = true})
+    box.schema.user.grant('test', 'read,write', 'sequence', 'test_seq')
+
     box.schema.user.create('no_grants')
 end)
 
@@ -4,12 +4,14 @@ package tarantool
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
 	"log"
 	"math"
+	"net"
 	"runtime"
 	"sync"
 	"sync/atomic"
@@ -52,6 +54,12 @@ const (
 	LogUnexpectedResultId
 	// LogWatchEventReadFailed is logged when failed to read a watch event.
 	LogWatchEventReadFailed
+	// LogConnectTimings is logged when Connect establishes a connection. It
+	// is a debug event and it is ignored by the default logger.
+	LogConnectTimings
+	// LogSchemaReloadFailed is logged when a reload of the schema after
+	// a schema change failed, the last loaded schema is still used.
+	LogSchemaReloadFailed
 )
 
 // ConnEvent is sent throw Notify channel specified in Opts.
@@ -69,7 +77,8 @@ type connWatchEvent struct {
 
 var epoch = time.Now()
 
-// Logger is logger type expected to be passed in options.
+// Logger is logger type expected to be passed in options. The connection
+// passed to Report could be identified with conn.Label() and conn.Addr().
 type Logger interface {
 	Report(event ConnLogKind, conn *Connection, v ...interface{})
 }
@@ -81,16 +90,21 @@ func (d defaultLogger) Report(event ConnLogKind, conn *Connection, v ...interfac
 	case LogReconnectFailed:
 		reconnects := v[0].(uint)
 		err := v[1].(error)
-		log.Printf("tarantool: reconnect (%d/%d) to %s failed: %s", reconnects, conn.opts.MaxReconnects, conn.addr, err)
+		log.Printf("tarantool: reconnect (%d/%d) to %s failed: %s", reconnects, conn.opts.MaxReconnects, conn.logName(), err)
 	case LogLastReconnectFailed:
 		err := v[0].(error)
-		log.Printf("tarantool: last reconnect to %s failed: %s, giving it up", conn.addr, err)
+		log.Printf("tarantool: last reconnect to %s failed: %s, giving it up", conn.logName(), err)
 	case LogUnexpectedResultId:
 		resp := v[0].(*Response)
-		log.Printf("tarantool: connection %s got unexpected resultId (%d) in response", conn.addr, resp.RequestId)
+		log.Printf("tarantool: connection %s got unexpected resultId (%d) in response", conn.logName(), resp.RequestId)
 	case LogWatchEventReadFailed:
 		err := v[0].(error)
-		log.Printf("tarantool: unable to parse watch event: %s", err)
+		log.Printf("tarantool: connection %s unable to parse watch event: %s", conn.logName(), err)
+	case LogSchemaReloadFailed:
+		err := v[0].(error)
+		log.Printf("tarantool: connection %s failed to reload schema: %s", conn.logName(), err)
+	case LogConnectTimings:
+		// Debug events are not reported by the default logger.
 	default:
 		args := append([]interface{}{"tarantool: unexpected event ", event, conn}, v...)
 		log.Print(args...)
@@ -143,7 +157,9 @@ type Connection struct {
 	c     Conn
 	mutex sync.Mutex
 	cond  *sync.Cond
-	// Schema contains schema loaded on connection.
+	// Schema contains schema loaded on connection. The schema is replaced
+	// by a reload in background, so direct reads of the field are not safe
+	// in concurrent code: use GetSchema instead.
 	Schema *Schema
 	// requestId contains the last request ID for requests with nil context.
 	requestId uint32
@@ -154,6 +170,10 @@ type Connection struct {
 
 	shard      []connShard
 	dirtyShard chan uint32
+	// urgentShard buffers urgent requests, it is written before other shards.
+	urgentShard connShard
+	// dirtyUrgent signals that the urgentShard has data to write.
+	dirtyUrgent chan struct{}
 
 	control chan struct{}
 	rlimit  chan struct{}
@@ -163,8 +183,15 @@ type Connection struct {
 	lenbuf  [PacketLengthBytes]byte
 
 	lastStreamId uint64
+	// schemaVersion is the last schema version received from the server.
+	schemaVersion uint64
+	// lastRead is a time since epoch of the last response read.
+	lastRead int64
 
 	serverProtocolInfo ProtocolInfo
+	// namesUseSupported is true if the server supports space and index
+	// names in requests.
+	namesUseSupported bool
 	// watchMap is a map of key -> chan watchState.
 	watchMap sync.Map
 
@@ -172,6 +199,68 @@ type Connection struct {
 	shutdownWatcher Watcher
 	// requestCnt is a counter of active requests.
 	requestCnt int64
+	// connectTimings contains durations of the connection establishment
+	// phases.
+	connectTimings ConnectTimings
+	// connectCtx cancels the first dial of ConnectContext.
+	connectCtx context.Context
+	// sessionId is a cached box.session.id() value of the sessionConn
+	// connection.
+	sessionId   uint64
+	sessionConn Conn
+
+	// schemaMutex serializes updates of the Schema by Opts.LazySchema,
+	// schema reloads and OverrideSchema.
+	schemaMutex sync.Mutex
+	// schemaReload signals the schema reloader goroutine to reload the
+	// schema, it is nil if the schema is not loaded on connect.
+	schemaReload chan struct{}
+	// schemaOverridden is true after OverrideSchema, the schema is not
+	// reloaded anymore. It is protected by schemaMutex.
+	schemaOverridden bool
+
+	// preparedMutex protects preparedCache and preparedGen.
+	preparedMutex sync.Mutex
+	// preparedCache is a map of SQL text -> prepared statement.
+	preparedCache map[string]*Prepared
+	// preparedGen is incremented each time the preparedCache is cleared.
+	preparedGen uint64
+
+	// interceptorsMutex serializes updates of interceptors.
+	interceptorsMutex sync.Mutex
+	// interceptors contains a []Interceptor chain used by Do.
+	interceptors atomic.Value
+	// retryBudget limits retries, it is nil if the budget is disabled.
+	retryBudget *retryBudget
+	// circuitBreaker is nil if the circuit breaker is disabled.
+	circuitBreaker *circuitBreaker
+	// inFlight limits unresolved futures, it is nil if the limit is
+	// disabled.
+	inFlight chan struct{}
+}
+
+// Interceptor is a function that wraps a request execution in
+// Connection.Do. It could inspect the request, call next to execute it and
+// inspect the returned future. An interceptor must call next to execute
+// the request, otherwise it should return its own future.
+type Interceptor func(req Request, next func(Request) *Future) *Future
+
+// ConnectTimings contains durations of the connection establishment phases.
+type ConnectTimings struct {
+	// Dial is a time spent to establish a network connection. If a custom
+	// Dialer is used it contains the whole Dialer.Dial call.
+	Dial time.Duration
+	// Handshake is a time spent to read the greeting, to identify the
+	// protocol and to authenticate. It is always zero for a custom Dialer.
+	Handshake time.Duration
+	// Schema is a time spent to load the schema. It is zero if
+	// Opts.SkipSchema or Opts.LazySchema is set.
+	Schema time.Duration
+}
+
+// Total returns a total time spent to establish a connection.
+func (timings ConnectTimings) Total() time.Duration {
+	return timings.Dial + timings.Handshake + timings.Schema
 }
 
 var _ = Connector(&Connection{}) // Check compatibility with connector interface.
@@ -225,9 +314,34 @@ type connShard struct {
 	requestsWithCtx [requestsMap]futureList
 	bufmut          sync.Mutex
 	buf             smallWBuf
-	enc             *encoder
+	// bufFutures contains futures of the requests in the buf.
+	bufFutures []*Future
+	enc        *encoder
+	// discarded contains ids of discarded futures, their responses are
+	// dropped silently. It is protected by rmut.
+	discarded map[uint32]struct{}
 }
 
+// WriteFlushMode is a strategy of flushing of written requests to the
+// network. It is a tradeoff between a request latency and a throughput:
+// each flush is a system call, so fewer flushes send more requests per call.
+type WriteFlushMode int
+
+const (
+	// FlushWhenIdle flushes requests when there are no more requests to
+	// write. It batches requests under a high load and does not delay
+	// requests under a low load.
+	FlushWhenIdle WriteFlushMode = iota
+	// FlushImmediate flushes each written batch of requests at once. It
+	// gives the lowest latency, but it makes more system calls under a high
+	// load.
+	FlushImmediate
+	// FlushBatched delays a flush up to Opts.WriteFlushDelay to collect more
+	// requests. It increases a throughput of small requests at the cost of
+	// a latency.
+	FlushBatched
+)
+
 // Opts is a way to configure Connection
 type Opts struct {
 	// Auth is an authentication method.
@@ -235,6 +349,11 @@ type Opts struct {
 	// Dialer is a Dialer object used to create a new connection to a
 	// Tarantool instance. TtDialer is a default one.
 	Dialer Dialer
+	// DialFunc is a function used by TtDialer to establish a network
+	// connection instead of net.Dial. It allows to use a proxy or to set
+	// socket options. The SSL transport is established over the returned
+	// connection.
+	DialFunc func(ctx context.Context, network, address string) (net.Conn, error)
 	// Timeout for response to a particular request. The timeout is reset when
 	// push messages are received. If Timeout is zero, any request can be
 	// blocked infinitely.
@@ -257,10 +376,24 @@ type Opts struct {
 	// endlessly.
 	// After MaxReconnects attempts Connection becomes closed.
 	MaxReconnects uint
+	// Keepalive is an interval of pings on an idle connection. If a ping is
+	// not answered within the interval, the connection is considered dead
+	// and it is reconnected or closed if Reconnect is disabled. It allows
+	// to keep a session alive behind firewalls and to detect a silently
+	// dropped session before a next request. The pings are not passed to
+	// interceptors and are not limited by MaxInFlight and CircuitBreaker.
+	// By default, pings are sent every Timeout/3 without the dead connection
+	// detection.
+	Keepalive time.Duration
 	// Username for logging in to Tarantool.
 	User string
 	// User password for logging in to Tarantool.
 	Pass string
+	// CredentialsProvider returns a user and a password for logging in to
+	// Tarantool. It is called on each connect and reconnect attempt and
+	// overrides User and Pass, so short-lived credentials could be used.
+	// An error from the provider fails the connection attempt.
+	CredentialsProvider func() (user, pass string, err error)
 	// RateLimit limits number of 'in-fly' request, i.e. already put into
 	// requests queue, but not yet answered by server or timeouted.
 	// It is disabled by default.
@@ -274,20 +407,70 @@ type Opts struct {
 	//                If no timeout period is set, it will wait forever.
 	// It is required if RateLimit is specified.
 	RLimitAction uint
+	// MaxInFlight limits a number of unresolved futures of the connection.
+	// A new request waits for a free slot, the wait is interrupted if the
+	// context of the request is done or the connection is closed. Unlike
+	// RateLimit it does not allocate a request id and does not start
+	// the request timeout before the slot is taken. It is disabled by
+	// default.
+	MaxInFlight uint
+	// MaxRequestSize limits a size of an encoded request in bytes. A larger
+	// request fails with ErrRequestTooLarge error before sending. It could
+	// be used to avoid a disconnect by the server for a request larger than
+	// its limits. It is disabled by default.
+	MaxRequestSize int
+	// SchemaReloadRetries is a number of retries of a schema reload. The
+	// schema loaded on connect is reloaded in background when a response
+	// contains a new schema version. Names are resolved with the last
+	// loaded schema until a reload succeeds. A reload is attempted once
+	// and is not retried if it is zero.
+	SchemaReloadRetries uint
 	// Concurrency is amount of separate mutexes for request
 	// queues and buffers inside of connection.
 	// It is rounded up to nearest power of 2.
 	// By default it is runtime.GOMAXPROCS(-1) * 4
 	Concurrency uint32
+	// WriteFlushMode is a strategy of flushing of written requests to
+	// the network. FlushWhenIdle is used by default.
+	WriteFlushMode WriteFlushMode
+	// WriteFlushDelay is a maximum delay of a flush in the FlushBatched
+	// mode.
+	WriteFlushDelay time.Duration
 	// SkipSchema disables schema loading. Without disabling schema loading,
 	// there is no way to create Connection for currently not accessible Tarantool.
 	SkipSchema bool
+	// LazySchema disables loading of the whole schema on connect. Instead,
+	// a space with indexes is loaded on the first request which uses a
+	// name of the space or the index. It is useful if an instance has a lot
+	// of spaces and only a few of them are used. Loaded spaces are not
+	// reloaded on schema changes.
+	LazySchema bool
 	// Notify is a channel which receives notifications about Connection status
 	// changes.
 	Notify chan<- ConnEvent
+	// OnSchemaChange is called when a response contains a schema version
+	// that differs from the previous one. It is called from the connection
+	// reader goroutine, so it should not block.
+	OnSchemaChange func(conn *Connection, version uint64)
+	// OnRequestStart is called with the connection label and a request
+	// code when a request is passed to the connection. It could be used to
+	// collect metrics. Internal requests of the connection like periodic
+	// pings are reported too.
+	OnRequestStart func(label string, code int32)
+	// OnRequestEnd is called with the connection label and a request code
+	// when a future of the request is finished, for asynchronous requests
+	// too. The duration is a time from the request sending to the response
+	// receiving, the error is a client error or an Error with a code of
+	// a server error. It is called from the connection goroutines, so it
+	// should not block.
+	OnRequestEnd func(label string, code int32, dur time.Duration, err error)
 	// Handle is user specified value, that could be retrivied with
 	// Handle() method.
 	Handle interface{}
+	// Label is a human-readable name of the connection. It is added to
+	// messages of the default logger and passed to OnRequestStart and
+	// OnRequestEnd callbacks.
+	Label string
 	// Logger is user specified logger used for error messages.
 	Logger Logger
 	// Transport is the connection type, by default the connection is unencrypted.
@@ -298,6 +481,12 @@ type Opts struct {
 	// list of protocol features that should be supported by
 	// Tarantool server. By default there are no restrictions.
 	RequiredProtocolInfo ProtocolInfo
+	// RetryBudget limits retries made with Future.Retry on the connection.
+	// It is disabled by default.
+	RetryBudget RetryBudget
+	// CircuitBreaker makes requests fail fast after consecutive failures.
+	// It is disabled by default.
+	CircuitBreaker CircuitBreaker
 }
 
 // SslOpts is a way to configure ssl transport.
@@ -320,6 +509,27 @@ type SslOpts struct {
 	//
 	// * https://www.openssl.org/docs/man1.1.1/man1/ciphers.html
 	Ciphers string
+	// MinVersion contains the minimum TLS version that is acceptable, for
+	// example, 0x0303 for TLS 1.2 (the values are the same as
+	// crypto/tls.VersionTLS* constants). TLS 1.2 is used by default.
+	MinVersion uint16
+	// MaxVersion contains the maximum TLS version that is acceptable. By
+	// default, it is equal to the maximum of MinVersion and TLS 1.2.
+	MaxVersion uint16
+	// CipherSuites is a list of TLS 1.2 cipher suites IDs (the values are
+	// the same as crypto/tls.TLS_* constants). The cipher suites are
+	// appended to Ciphers. An error is returned on dial if a cipher suite
+	// is unknown or OpenSSL does not support any of the cipher suites.
+	CipherSuites []uint16
+	// GetClientCertificate is called on each SSL handshake to get a client
+	// certificate chain and a private key, like the crypto/tls hook. It
+	// allows to rotate the client certificate without recreating
+	// a connection: a new certificate is used after a reconnect. CertFile
+	// and KeyFile are ignored if it is set.
+	//
+	// The certificate is passed to OpenSSL, so only Certificate and
+	// PrivateKey fields are used.
+	GetClientCertificate func() (*tls.Certificate, error)
 }
 
 // Clone returns a copy of the Opts object.
@@ -352,6 +562,15 @@ func (opts Opts) Clone() Opts {
 // fails. But if Tarantool is not reachable, then it will make an attempt to reconnect later
 // and will not finish to make attempts on authorization failures.
 func Connect(addr string, opts Opts) (conn *Connection, err error) {
+	return ConnectContext(context.Background(), addr, opts)
+}
+
+// ConnectContext creates and configures a new Connection as Connect does.
+// The context cancels the first dial and handshake if it is done, an error
+// is returned in the case even if opts.Reconnect is non-zero. The context
+// does not affect the connection after it has been created.
+func ConnectContext(ctx context.Context, addr string,
+	opts Opts) (conn *Connection, err error) {
 	conn = &Connection{
 		addr:             addr,
 		requestId:        0,
@@ -375,6 +594,7 @@ func Connect(addr string, opts Opts) (conn *Connection, err error) {
 		conn.opts.Concurrency = c + 1
 	}
 	conn.dirtyShard = make(chan uint32, conn.opts.Concurrency*2)
+	conn.dirtyUrgent = make(chan struct{}, 1)
 	conn.shard = make([]connShard, conn.opts.Concurrency)
 	for i := range conn.shard {
 		shard := &conn.shard[i]
@@ -386,6 +606,20 @@ func Connect(addr string, opts Opts) (conn *Connection, err error) {
 		}
 	}
 
+	if conn.opts.RetryBudget.Burst > 0 {
+		conn.retryBudget = newRetryBudget(conn.opts.RetryBudget)
+	}
+	if conn.opts.CircuitBreaker.Threshold > 0 {
+		conn.circuitBreaker = newCircuitBreaker(conn.opts.CircuitBreaker)
+	}
+
+	if conn.opts.MaxInFlight > 0 {
+		conn.inFlight = make(chan struct{}, conn.opts.MaxInFlight)
+	}
+	if !conn.opts.SkipSchema && !conn.opts.LazySchema {
+		conn.schemaReload = make(chan struct{}, 1)
+	}
+
 	if conn.opts.RateLimit > 0 {
 		conn.rlimit = make(chan struct{}, conn.opts.RateLimit)
 		if conn.opts.RLimitAction != RLimitDrop && conn.opts.RLimitAction != RLimitWait {
@@ -399,9 +633,15 @@ func Connect(addr string, opts Opts) (conn *Connection, err error) {
 
 	conn.cond = sync.NewCond(&conn.mutex)
 
-	if err = conn.createConnection(false); err != nil {
+	// A context which could not be done is not passed to the Dialer.
+	if ctx.Done() != nil {
+		conn.connectCtx = ctx
+	}
+	err = conn.createConnection(false)
+	conn.connectCtx = nil
+	if err != nil {
 		ter, ok := err.(Error)
-		if conn.opts.Reconnect <= 0 {
+		if conn.opts.Reconnect <= 0 || ctx.Err() != nil {
 			return nil, err
 		} else if ok && (ter.Code == ErrNoSuchUser ||
 			ter.Code == ErrPasswordMismatch) {
@@ -426,13 +666,22 @@ func Connect(addr string, opts Opts) (conn *Connection, err error) {
 	}
 
 	// TODO: reload schema after reconnect.
-	if !conn.opts.SkipSchema {
+	if !conn.opts.SkipSchema && !conn.opts.LazySchema {
+		start := time.Now()
 		if err = conn.loadSchema(); err != nil {
 			conn.mutex.Lock()
 			defer conn.mutex.Unlock()
 			conn.closeConnection(err, true)
 			return nil, err
 		}
+		conn.mutex.Lock()
+		conn.connectTimings.Schema = time.Since(start)
+		conn.mutex.Unlock()
+		go conn.schemaReloader()
+	}
+
+	if conn.ConnectedNow() {
+		conn.opts.Logger.Report(LogConnectTimings, conn, conn.ConnectTimings())
 	}
 
 	return conn, err
@@ -457,6 +706,35 @@ func (conn *Connection) Close() error {
 	return conn.closeConnection(err, true)
 }
 
+// Reconnect closes the current connection and establishes a new one
+// immediately. In-flight requests fail with a temporary ClientError with
+// the ErrConnectionNotReady code. If the connect fails, the error is
+// returned and the connection is reconnected as usual if Opts.Reconnect is
+// set or it is closed otherwise.
+func (conn *Connection) Reconnect() error {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+	defer conn.cond.Broadcast()
+
+	if atomic.LoadUint32(&conn.state) == connClosed {
+		return ClientError{ErrConnectionClosed, "using closed connection"}
+	}
+
+	conn.closeConnection(ClientError{
+		ErrConnectionNotReady,
+		"connection is reconnected by client",
+	}, false)
+	err := conn.createConnection(false)
+	if err != nil {
+		if conn.opts.Reconnect > 0 {
+			go conn.reconnect(err, nil)
+		} else {
+			conn.closeConnection(err, true)
+		}
+	}
+	return err
+}
+
 // Addr returns a configured address of Tarantool socket.
 func (conn *Connection) Addr() string {
 	return conn.addr
@@ -482,11 +760,70 @@ func (conn *Connection) LocalAddr() string {
 	return conn.c.LocalAddr().String()
 }
 
+// ConnectTimings returns durations of the connection establishment phases.
+// Dial and Handshake are updated after each successful reconnect.
+func (conn *Connection) ConnectTimings() ConnectTimings {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+	return conn.connectTimings
+}
+
+// ConnectDuration returns a total time spent to establish the connection:
+// dial, handshake and schema loading.
+func (conn *Connection) ConnectDuration() time.Duration {
+	return conn.ConnectTimings().Total()
+}
+
+// SessionId returns box.session.id() of the connection on the server side.
+// The value is requested once and cached until a reconnect.
+func (conn *Connection) SessionId() (uint64, error) {
+	conn.mutex.Lock()
+	c := conn.c
+	if c != nil && c == conn.sessionConn {
+		id := conn.sessionId
+		conn.mutex.Unlock()
+		return id, nil
+	}
+	conn.mutex.Unlock()
+
+	var ids []uint64
+	err := conn.EvalTyped("return box.session.id()", []interface{}{}, &ids)
+	if err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, errors.New("unexpected response: no data")
+	}
+
+	conn.mutex.Lock()
+	// The value could be received from a new connection after a reconnect,
+	// so it is cached only for the same connection.
+	if c != nil && c == conn.c {
+		conn.sessionId = ids[0]
+		conn.sessionConn = c
+	}
+	conn.mutex.Unlock()
+	return ids[0], nil
+}
+
 // Handle returns a user-specified handle from Opts.
 func (conn *Connection) Handle() interface{} {
 	return conn.opts.Handle
 }
 
+// Label returns a user-specified label from Opts.
+func (conn *Connection) Label() string {
+	return conn.opts.Label
+}
+
+// logName returns a name of the connection for log messages.
+func (conn *Connection) logName() string {
+	if conn.opts.Label == "" {
+		return conn.addr
+	}
+	return fmt.Sprintf("%s (%s)", conn.opts.Label, conn.addr)
+}
+
 func (conn *Connection) cancelFuture(fut *Future, err error) {
 	if fut = conn.fetchFuture(fut.requestId); fut != nil {
 		fut.SetError(err)
@@ -503,22 +840,39 @@ func (conn *Connection) dial() (err error) {
 		dialTimeout = 5 * time.Second
 	}
 
+	user, pass := opts.User, opts.Pass
+	if opts.CredentialsProvider != nil {
+		if user, pass, err = opts.CredentialsProvider(); err != nil {
+			return fmt.Errorf("failed to get credentials: %w", err)
+		}
+	}
+
 	var c Conn
+	start := time.Now()
 	c, err = conn.opts.Dialer.Dial(conn.addr, DialOpts{
 		DialTimeout:      dialTimeout,
+		DialFunc:         opts.DialFunc,
 		IoTimeout:        opts.Timeout,
 		Transport:        opts.Transport,
 		Ssl:              opts.Ssl,
 		RequiredProtocol: opts.RequiredProtocolInfo,
 		Auth:             opts.Auth,
-		User:             opts.User,
-		Password:         opts.Pass,
+		User:             user,
+		Password:         pass,
+		Context:          conn.connectCtx,
 	})
 	if err != nil {
 		return
 	}
+	timings := ConnectTimings{Dial: time.Since(start)}
+	if tc, ok := c.(*tntConn); ok {
+		timings.Handshake = timings.Dial - tc.dialDuration
+		timings.Dial = tc.dialDuration
+	}
 
-	conn.Greeting.Version = c.Greeting().Version
+	greeting := c.Greeting()
+	conn.Greeting.Version = greeting.Version
+	conn.Greeting.Salt = greeting.Salt
 	conn.serverProtocolInfo = c.ProtocolInfo()
 
 	// Watchers.
@@ -547,8 +901,12 @@ func (conn *Connection) dial() (err error) {
 	}
 
 	// Only if connected and fully initialized.
+	conn.connectTimings.Dial = timings.Dial
+	conn.connectTimings.Handshake = timings.Handshake
 	conn.lockShards()
 	conn.c = c
+	conn.namesUseSupported = isFeatureInSlice(SpaceAndIndexNamesFeature,
+		conn.serverProtocolInfo.Features)
 	atomic.StoreUint32(&conn.state, connConnected)
 	conn.cond.Broadcast()
 	conn.unlockShards()
@@ -573,6 +931,7 @@ func pack(h *smallWBuf, enc *encoder, reqid uint32,
 	const uint64Code = 0xcf
 	const streamBytesLenUint64 = 10
 	const streamBytesLenUint32 = 6
+	const schemaBytesLenUint64 = 10
 
 	hl := h.Len()
 
@@ -593,6 +952,16 @@ func pack(h *smallWBuf, enc *encoder, reqid uint32,
 		}
 	}
 
+	var schemaBytesLen = 0
+	var schemaBytes [schemaBytesLenUint64]byte
+	if version, ok := requestSchemaVersion(req); ok {
+		hMapLen++
+		schemaBytesLen = schemaBytesLenUint64
+		schemaBytes[0] = KeySchemaVersion
+		schemaBytes[1] = uint64Code
+		binary.BigEndian.PutUint64(schemaBytes[2:], version)
+	}
+
 	hBytes := append([]byte{
 		uint32Code, 0, 0, 0, 0, // Length.
 		hMapLen,
@@ -601,6 +970,7 @@ func pack(h *smallWBuf, enc *encoder, reqid uint32,
 		byte(reqid >> 24), byte(reqid >> 16),
 		byte(reqid >> 8), byte(reqid),
 	}, streamBytes[:streamBytesLen]...)
+	hBytes = append(hBytes, schemaBytes[:schemaBytesLen]...)
 
 	h.Write(hBytes)
 
@@ -671,8 +1041,16 @@ func (conn *Connection) closeConnection(neterr error, forever bool) (err error)
 		err = conn.c.Close()
 		conn.c = nil
 	}
+	// Prepared statements are valid only within a session.
+	conn.clearPreparedCache()
+	conn.urgentShard.bufmut.Lock()
+	conn.urgentShard.buf.Reset()
+	conn.urgentShard.bufFutures = conn.urgentShard.bufFutures[:0]
+	conn.urgentShard.bufmut.Unlock()
 	for i := range conn.shard {
 		conn.shard[i].buf.Reset()
+		conn.shard[i].bufFutures = conn.shard[i].bufFutures[:0]
+		conn.shard[i].discarded = nil
 		requestsLists := []*[requestsMap]futureList{&conn.shard[i].requests, &conn.shard[i].requestsWithCtx}
 		for _, requests := range requestsLists {
 			for pos := range requests {
@@ -691,7 +1069,7 @@ func (conn *Connection) reconnectImpl(neterr error, c Conn) {
 				conn.closeConnection(err, true)
 			}
 		}
-	} else {
+	} else if c == conn.c {
 		conn.closeConnection(neterr, true)
 	}
 }
@@ -722,7 +1100,11 @@ func (conn *Connection) pinger() {
 	if to == 0 {
 		to = 3 * time.Second
 	}
-	t := time.NewTicker(to / 3)
+	interval := to / 3
+	if conn.opts.Keepalive > 0 {
+		interval = conn.opts.Keepalive
+	}
+	t := time.NewTicker(interval)
 	defer t.Stop()
 	for {
 		select {
@@ -730,7 +1112,40 @@ func (conn *Connection) pinger() {
 			return
 		case <-t.C:
 		}
-		conn.Ping()
+		if conn.opts.Keepalive > 0 {
+			conn.keepalive()
+		} else {
+			conn.Ping()
+		}
+	}
+}
+
+// keepalive pings an idle connection and reconnects it if the ping is not
+// answered within Opts.Keepalive.
+func (conn *Connection) keepalive() {
+	idle := time.Since(epoch) - time.Duration(atomic.LoadInt64(&conn.lastRead))
+	if idle < conn.opts.Keepalive/2 || !conn.ConnectedNow() {
+		return
+	}
+
+	conn.mutex.Lock()
+	c := conn.c
+	conn.mutex.Unlock()
+	if c == nil {
+		return
+	}
+
+	// The ping bypasses interceptors, Opts.MaxInFlight and the circuit
+	// breaker: it checks the connection, not the user load.
+	ctx, cancel := context.WithTimeout(context.Background(), conn.opts.Keepalive)
+	defer cancel()
+	req := NewPingRequest().Context(ctx)
+	if _, err := conn.sendRequest(req, ignoreStreamId, true).Get(); err != nil &&
+		ctx.Err() != nil {
+		conn.reconnect(ClientError{
+			ErrTimeouted,
+			"keepalive ping is not answered",
+		}, c)
 	}
 }
 
@@ -746,39 +1161,97 @@ func (conn *Connection) notify(kind ConnEventKind) {
 func (conn *Connection) writer(w writeFlusher, c Conn) {
 	var shardn uint32
 	var packet smallWBuf
+	var futures []*Future
+	// flushAt is a time to flush written data in the FlushBatched mode.
+	var flushAt time.Time
 	for atomic.LoadUint32(&conn.state) != connClosed {
+		urgent := false
 		select {
-		case shardn = <-conn.dirtyShard:
+		case <-conn.dirtyUrgent:
+			urgent = true
 		default:
-			runtime.Gosched()
-			if len(conn.dirtyShard) == 0 {
-				if err := w.Flush(); err != nil {
-					conn.reconnect(err, c)
-					return
-				}
-			}
 			select {
+			case <-conn.dirtyUrgent:
+				urgent = true
 			case shardn = <-conn.dirtyShard:
-			case <-conn.control:
-				return
+			default:
+				runtime.Gosched()
+				var flushTimer *time.Timer
+				var flushC <-chan time.Time
+				if len(conn.dirtyShard) == 0 && len(conn.dirtyUrgent) == 0 {
+					wait := time.Until(flushAt)
+					if conn.opts.WriteFlushMode == FlushBatched && wait > 0 {
+						flushTimer = time.NewTimer(wait)
+						flushC = flushTimer.C
+					} else if err := w.Flush(); err != nil {
+						conn.reconnect(err, c)
+						return
+					}
+				}
+				flushed := false
+				select {
+				case <-conn.dirtyUrgent:
+					urgent = true
+				case shardn = <-conn.dirtyShard:
+				case <-flushC:
+					flushed = true
+				case <-conn.control:
+					return
+				}
+				if flushTimer != nil {
+					flushTimer.Stop()
+				}
+				if flushed {
+					if err := w.Flush(); err != nil {
+						conn.reconnect(err, c)
+						return
+					}
+					flushAt = time.Time{}
+					continue
+				}
 			}
 		}
-		shard := &conn.shard[shardn]
+		shard := &conn.urgentShard
+		if !urgent {
+			shard = &conn.shard[shardn]
+		}
 		shard.bufmut.Lock()
 		if conn.c != c {
-			conn.dirtyShard <- shardn
+			if urgent {
+				conn.dirtyUrgent <- struct{}{}
+			} else {
+				conn.dirtyShard <- shardn
+			}
 			shard.bufmut.Unlock()
 			return
 		}
 		packet, shard.buf = shard.buf, packet
+		futures, shard.bufFutures = shard.bufFutures, futures
 		shard.bufmut.Unlock()
 		if packet.Len() == 0 {
 			continue
 		}
+		now := int64(time.Since(epoch))
+		for i, fut := range futures {
+			atomic.StoreInt64(&fut.writtenAt, now)
+			futures[i] = nil
+		}
+		futures = futures[:0]
 		if _, err := w.Write(packet.b); err != nil {
 			conn.reconnect(err, c)
 			return
 		}
+		switch conn.opts.WriteFlushMode {
+		case FlushImmediate:
+			if err := w.Flush(); err != nil {
+				conn.reconnect(err, c)
+				return
+			}
+		case FlushBatched:
+			if flushAt.IsZero() {
+				flushAt = time.Now().Add(conn.opts.WriteFlushDelay)
+			}
+		}
 		packet.Reset()
 	}
 }
@@ -835,6 +1308,7 @@ func (conn *Connection) reader(r io.Reader, c Conn) {
 			conn.reconnect(err, c)
 			return
 		}
+		atomic.StoreInt64(&conn.lastRead, int64(time.Since(epoch)))
 		resp := &Response{buf: smallBuf{b: respBytes}}
 		err = resp.decodeHeader(conn.dec)
 		if err != nil {
@@ -842,6 +1316,10 @@ func (conn *Connection) reader(r io.Reader, c Conn) {
 			return
 		}
 
+		if resp.SchemaVersion != 0 {
+			conn.updateSchemaVersion(resp.SchemaVersion)
+		}
+
 		var fut *Future = nil
 		if resp.Code == EventCode {
 			if event, err := readWatchEvent(&resp.buf); err == nil {
@@ -861,12 +1339,30 @@ func (conn *Connection) reader(r io.Reader, c Conn) {
 			}
 		}
 
-		if fut == nil {
+		if fut == nil && !conn.dropDiscarded(resp.RequestId, resp.Code != PushCode) {
 			conn.opts.Logger.Report(LogUnexpectedResultId, conn, resp)
 		}
 	}
 }
 
+// updateSchemaVersion stores the schema version and calls
+// Opts.OnSchemaChange if the version has changed.
+func (conn *Connection) updateSchemaVersion(version uint64) {
+	prev := atomic.SwapUint64(&conn.schemaVersion, version)
+	if prev == 0 || prev == version {
+		return
+	}
+	if conn.schemaReload != nil {
+		select {
+		case conn.schemaReload <- struct{}{}:
+		default:
+		}
+	}
+	if conn.opts.OnSchemaChange != nil {
+		conn.opts.OnSchemaChange(conn, version)
+	}
+}
+
 // eventer goroutine gets watch events and updates values for watchers.
 func (conn *Connection) eventer(events <-chan connWatchEvent) {
 	for event := range events {
@@ -892,8 +1388,12 @@ func (conn *Connection) eventer(events <-chan connWatchEvent) {
 	}
 }
 
-func (conn *Connection) newFuture(ctx context.Context) (fut *Future) {
+func (conn *Connection) newFuture(req Request, internal bool) (fut *Future) {
+	ctx := req.Ctx()
 	fut = NewFuture()
+	fut.req = req
+	fut.conn = conn
+	fut.internal = internal
 	if conn.rlimit != nil && conn.opts.RLimitAction == RLimitDrop {
 		select {
 		case conn.rlimit <- struct{}{}:
@@ -913,30 +1413,24 @@ func (conn *Connection) newFuture(ctx context.Context) (fut *Future) {
 	shard.rmut.Lock()
 	switch atomic.LoadUint32(&conn.state) {
 	case connClosed:
-		fut.err = ClientError{
+		conn.failNewFuture(fut, ClientError{
 			ErrConnectionClosed,
 			"using closed connection",
-		}
-		fut.ready = nil
-		fut.done = nil
+		})
 		shard.rmut.Unlock()
 		return
 	case connDisconnected:
-		fut.err = ClientError{
+		conn.failNewFuture(fut, ClientError{
 			ErrConnectionNotReady,
 			"client connection is not ready",
-		}
-		fut.ready = nil
-		fut.done = nil
+		})
 		shard.rmut.Unlock()
 		return
 	case connShutdown:
-		fut.err = ClientError{
+		conn.failNewFuture(fut, ClientError{
 			ErrConnectionShutdown,
 			"server shutdown in progress",
-		}
-		fut.ready = nil
-		fut.done = nil
+		})
 		shard.rmut.Unlock()
 		return
 	}
@@ -944,13 +1438,15 @@ func (conn *Connection) newFuture(ctx context.Context) (fut *Future) {
 	if ctx != nil {
 		select {
 		case <-ctx.Done():
-			fut.SetError(fmt.Errorf("context is done"))
+			conn.failNewFuture(fut, fmt.Errorf("context is done"))
 			shard.rmut.Unlock()
 			return
 		default:
 		}
+		fut.sentAt = time.Since(epoch)
 		shard.requestsWithCtx[pos].addFuture(fut)
 	} else {
+		fut.sentAt = time.Since(epoch)
 		shard.requests[pos].addFuture(fut)
 		if conn.opts.Timeout > 0 {
 			fut.timeout = time.Since(epoch) + conn.opts.Timeout
@@ -974,6 +1470,17 @@ func (conn *Connection) newFuture(ctx context.Context) (fut *Future) {
 	return
 }
 
+// failNewFuture finishes a future which is not added to the requests queue
+// with the error. The caller is responsible for the request end, see send.
+func (conn *Connection) failNewFuture(fut *Future, err error) {
+	fut.err = err
+	fut.ready = nil
+	fut.done = nil
+	if conn.rlimit != nil && conn.opts.RLimitAction == RLimitDrop {
+		<-conn.rlimit
+	}
+}
+
 // This method removes a future from the internal queue if the context
 // is "done" before the response is come.
 func (conn *Connection) contextWatchdog(fut *Future, ctx context.Context) {
@@ -990,6 +1497,29 @@ func (conn *Connection) contextWatchdog(fut *Future, ctx context.Context) {
 	}
 }
 
+// acquireInFlight waits for a free slot of Opts.MaxInFlight.
+func (conn *Connection) acquireInFlight(ctx context.Context) error {
+	var done <-chan struct{}
+	if ctx != nil {
+		done = ctx.Done()
+	}
+	select {
+	case conn.inFlight <- struct{}{}:
+		return nil
+	case <-done:
+		return fmt.Errorf("context is done")
+	case <-conn.control:
+		return ClientError{ErrConnectionClosed, "using closed connection"}
+	}
+}
+
+// releaseInFlight frees a slot of Opts.MaxInFlight.
+func (conn *Connection) releaseInFlight() {
+	if conn.inFlight != nil {
+		<-conn.inFlight
+	}
+}
+
 func (conn *Connection) incrementRequestCnt() {
 	atomic.AddInt64(&conn.requestCnt, int64(1))
 }
@@ -1001,11 +1531,55 @@ func (conn *Connection) decrementRequestCnt() {
 }
 
 func (conn *Connection) send(req Request, streamId uint64) *Future {
+	return conn.sendRequest(req, streamId, false)
+}
+
+// sendRequest sends the request. An internal request bypasses
+// Opts.MaxInFlight and the circuit breaker.
+func (conn *Connection) sendRequest(req Request, streamId uint64,
+	internal bool) *Future {
+	if conn.opts.LazySchema {
+		if err := conn.loadSpaceLazy(req); err != nil {
+			fut := NewFuture()
+			fut.SetError(err)
+			return fut
+		}
+	}
+	if ctx := req.Ctx(); ctx != nil && ctx.Err() != nil {
+		fut := NewFuture()
+		fut.SetError(fmt.Errorf("context is done"))
+		return fut
+	}
+	if conn.inFlight != nil && !internal {
+		if err := conn.acquireInFlight(req.Ctx()); err != nil {
+			fut := NewFuture()
+			fut.SetError(err)
+			return fut
+		}
+	}
+	if conn.circuitBreaker != nil && !internal &&
+		!conn.circuitBreaker.allow() {
+		conn.releaseInFlight()
+		fut := NewFuture()
+		fut.SetError(ClientError{ErrCircuitOpen, "circuit breaker is open"})
+		return fut
+	}
+
 	conn.incrementRequestCnt()
+	if conn.opts.OnRequestStart != nil {
+		conn.opts.OnRequestStart(conn.opts.Label, req.Code())
+	}
 
-	fut := conn.newFuture(req.Ctx())
+	// Each exit after this point must finish the request with markDone or
+	// with the calls below: it releases the MaxInFlight slot, reports
+	// the result to the circuit breaker and calls OnRequestEnd.
+	fut := conn.newFuture(req, internal)
 	if fut.ready == nil {
+		if !internal {
+			conn.releaseInFlight()
+		}
 		conn.decrementRequestCnt()
+		conn.requestEnd(fut)
 		return fut
 	}
 
@@ -1026,6 +1600,10 @@ func (conn *Connection) send(req Request, streamId uint64) *Future {
 func (conn *Connection) putFuture(fut *Future, req Request, streamId uint64) {
 	shardn := fut.requestId & (conn.opts.Concurrency - 1)
 	shard := &conn.shard[shardn]
+	urgent := isUrgentRequest(req)
+	if urgent {
+		shard = &conn.urgentShard
+	}
 	shard.bufmut.Lock()
 	select {
 	case <-fut.done:
@@ -1040,7 +1618,16 @@ func (conn *Connection) putFuture(fut *Future, req Request, streamId uint64) {
 	}
 	blen := shard.buf.Len()
 	reqid := fut.requestId
-	if err := pack(&shard.buf, shard.enc, reqid, req, streamId, conn.Schema); err != nil {
+	err := pack(&shard.buf, shard.enc, reqid, req, streamId, conn.resolver())
+	if size := shard.buf.Len() - blen; err == nil &&
+		conn.opts.MaxRequestSize > 0 && size > conn.opts.MaxRequestSize {
+		err = ClientError{
+			ErrRequestTooLarge,
+			fmt.Sprintf("request size %d exceeds the limit %d", size,
+				conn.opts.MaxRequestSize),
+		}
+	}
+	if err != nil {
 		shard.buf.Trunc(blen)
 		shard.bufmut.Unlock()
 		if f := conn.fetchFuture(reqid); f == fut {
@@ -1064,6 +1651,7 @@ func (conn *Connection) putFuture(fut *Future, req Request, streamId uint64) {
 		}
 		return
 	}
+	shard.bufFutures = append(shard.bufFutures, fut)
 	shard.bufmut.Unlock()
 
 	if req.Async() {
@@ -1078,15 +1666,56 @@ func (conn *Connection) putFuture(fut *Future, req Request, streamId uint64) {
 	}
 
 	if firstWritten {
-		conn.dirtyShard <- shardn
+		if urgent {
+			conn.dirtyUrgent <- struct{}{}
+		} else {
+			conn.dirtyShard <- shardn
+		}
 	}
 }
 
+// resolver returns a SchemaResolver for requests. Space and index names are
+// sent as is if the server supports it.
+func (conn *Connection) resolver() SchemaResolver {
+	if conn.namesUseSupported {
+		return schemaNamesResolver{conn.Schema}
+	}
+	return conn.Schema
+}
+
 func (conn *Connection) markDone(fut *Future) {
 	if conn.rlimit != nil {
 		<-conn.rlimit
 	}
+	if !fut.internal {
+		conn.releaseInFlight()
+	}
 	conn.decrementRequestCnt()
+	conn.requestEnd(fut)
+}
+
+// requestEnd calls Opts.OnRequestEnd for a finished future.
+func (conn *Connection) requestEnd(fut *Future) {
+	if fut.req == nil ||
+		(conn.opts.OnRequestEnd == nil && conn.circuitBreaker == nil) {
+		return
+	}
+
+	err := fut.err
+	if err == nil && fut.resp != nil && fut.resp.Code != OkCode &&
+		fut.resp.Code != PushCode {
+		err = Error{Code: fut.resp.Code &^ ErrorCodeBit}
+	}
+	if conn.circuitBreaker != nil && !fut.internal {
+		conn.circuitBreaker.report(err)
+	}
+	if conn.opts.OnRequestEnd != nil {
+		var dur time.Duration
+		if fut.sentAt != 0 {
+			dur = fut.doneAt - fut.sentAt
+		}
+		conn.opts.OnRequestEnd(conn.opts.Label, fut.req.Code(), dur, err)
+	}
 }
 
 func (conn *Connection) peekFuture(reqid uint32) (fut *Future) {
@@ -1109,6 +1738,42 @@ func (conn *Connection) peekFuture(reqid uint32) (fut *Future) {
 	return fut
 }
 
+// discardFuture removes the future from the pending requests, a response
+// for it is dropped silently.
+func (conn *Connection) discardFuture(fut *Future) {
+	shard := &conn.shard[fut.requestId&(conn.opts.Concurrency-1)]
+	shard.rmut.Lock()
+	found := conn.getFutureImp(fut.requestId, true)
+	if found == fut {
+		if shard.discarded == nil {
+			shard.discarded = make(map[uint32]struct{})
+		}
+		shard.discarded[fut.requestId] = struct{}{}
+	}
+	shard.rmut.Unlock()
+
+	if found == fut {
+		fut.SetError(fmt.Errorf("the future is discarded"))
+		conn.markDone(fut)
+	}
+}
+
+// dropDiscarded returns true if the request id belongs to a discarded
+// future. The id is forgotten if the response is final.
+func (conn *Connection) dropDiscarded(reqid uint32, final bool) bool {
+	shard := &conn.shard[reqid&(conn.opts.Concurrency-1)]
+	shard.rmut.Lock()
+	defer shard.rmut.Unlock()
+
+	if _, ok := shard.discarded[reqid]; !ok {
+		return false
+	}
+	if final {
+		delete(shard.discarded, reqid)
+	}
+	return true
+}
+
 func (conn *Connection) fetchFuture(reqid uint32) (fut *Future) {
 	shard := &conn.shard[reqid&(conn.opts.Concurrency-1)]
 	shard.rmut.Lock()
@@ -1215,8 +1880,74 @@ func (conn *Connection) nextRequestId(context bool) (requestId uint32) {
 // An error is returned if the request was formed incorrectly, or failed to
 // create the future.
 func (conn *Connection) Do(req Request) *Future {
-	if connectedReq, ok := req.(ConnectedRequest); ok {
-		if connectedReq.Conn() != conn {
+	interceptors, _ := conn.interceptors.Load().([]Interceptor)
+	if len(interceptors) == 0 {
+		return conn.do(req)
+	}
+	return chainInterceptors(interceptors, conn.do)(req)
+}
+
+// SchemaVersion returns the last schema version received from the server or
+// 0 if there were no responses with a schema version yet.
+func (conn *Connection) SchemaVersion() uint64 {
+	return atomic.LoadUint64(&conn.schemaVersion)
+}
+
+// RetryBudget returns a number of available tokens in the retry budget or
+// +Inf if the budget is disabled.
+func (conn *Connection) RetryBudget() float64 {
+	if conn.retryBudget == nil {
+		return math.Inf(1)
+	}
+	return conn.retryBudget.available()
+}
+
+// CircuitOpen returns true if requests fail fast with ErrCircuitOpen error
+// at the moment, see Opts.CircuitBreaker.
+func (conn *Connection) CircuitOpen() bool {
+	if conn.circuitBreaker == nil {
+		return false
+	}
+	return conn.circuitBreaker.isOpen()
+}
+
+// Use adds interceptors to the chain which is executed on each Do call. The
+// interceptors are called in the order they were added: the first added
+// interceptor is the outermost one.
+//
+// Internal requests of the connection pass through the interceptors too:
+// pings sent every Opts.Timeout/3, watch requests, schema loading requests
+// and so on. Check req.Code() to handle only some requests. Pings of
+// Opts.Keepalive bypass the interceptors.
+func (conn *Connection) Use(interceptors ...Interceptor) {
+	conn.interceptorsMutex.Lock()
+	defer conn.interceptorsMutex.Unlock()
+
+	current, _ := conn.interceptors.Load().([]Interceptor)
+	chain := make([]Interceptor, 0, len(current)+len(interceptors))
+	chain = append(chain, current...)
+	chain = append(chain, interceptors...)
+	conn.interceptors.Store(chain)
+}
+
+// chainInterceptors builds a function which calls the interceptors in order
+// and the handler at the end.
+func chainInterceptors(interceptors []Interceptor,
+	handler func(Request) *Future) func(Request) *Future {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], handler
+		handler = func(req Request) *Future {
+			return interceptor(req, next)
+		}
+	}
+	return handler
+}
+
+// do performs a request asynchronously on the connection without
+// interceptors.
+func (conn *Connection) do(req Request) *Future {
+	if reqConn := requestConn(req); reqConn != nil {
+		if reqConn != conn {
 			fut := NewFuture()
 			fut.SetError(fmt.Errorf("the passed connected request doesn't belong to the current connection or connection pool"))
 			return fut
@@ -1230,18 +1961,31 @@ func (conn *Connection) ConfiguredTimeout() time.Duration {
 	return conn.opts.Timeout
 }
 
-// OverrideSchema sets Schema for the connection.
+// OverrideSchema sets Schema for the connection. The schema is not reloaded
+// after a schema change anymore.
 func (conn *Connection) OverrideSchema(s *Schema) {
 	if s != nil {
+		conn.schemaMutex.Lock()
+		defer conn.schemaMutex.Unlock()
 		conn.mutex.Lock()
 		defer conn.mutex.Unlock()
 		conn.lockShards()
 		defer conn.unlockShards()
 
 		conn.Schema = s
+		conn.schemaOverridden = true
 	}
 }
 
+// GetSchema returns the current schema of the connection. It is safe to call
+// it concurrently with a schema reload.
+func (conn *Connection) GetSchema() *Schema {
+	conn.schemaMutex.Lock()
+	defer conn.schemaMutex.Unlock()
+
+	return conn.Schema
+}
+
 // NewPrepared passes a sql statement to Tarantool for preparation synchronously.
 func (conn *Connection) NewPrepared(expr string) (*Prepared, error) {
 	req := NewPrepareRequest(expr)
@@ -1252,6 +1996,54 @@ func (conn *Connection) NewPrepared(expr string) (*Prepared, error) {
 	return NewPreparedFromResponse(conn, resp)
 }
 
+// PrepareCached returns a prepared statement for the sql statement from a
+// connection cache. The statement is prepared synchronously and stored in
+// the cache on a cache miss.
+//
+// The cache is cleared on disconnect because prepared statements are valid
+// only within a session. Do not Unprepare a statement returned by the
+// method, it may be used by other goroutines.
+func (conn *Connection) PrepareCached(expr string) (*Prepared, error) {
+	conn.preparedMutex.Lock()
+	if stmt, ok := conn.preparedCache[expr]; ok {
+		conn.preparedMutex.Unlock()
+		return stmt, nil
+	}
+	gen := conn.preparedGen
+	conn.preparedMutex.Unlock()
+
+	stmt, err := conn.NewPrepared(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.preparedMutex.Lock()
+	if gen != conn.preparedGen {
+		// The statement could be prepared in a previous session.
+		conn.preparedMutex.Unlock()
+		return stmt, nil
+	}
+	if cached, ok := conn.preparedCache[expr]; ok {
+		conn.preparedMutex.Unlock()
+		// It has been prepared concurrently, the duplicate is not needed.
+		conn.Do(NewUnprepareRequest(stmt))
+		return cached, nil
+	}
+	if conn.preparedCache == nil {
+		conn.preparedCache = make(map[string]*Prepared)
+	}
+	conn.preparedCache[expr] = stmt
+	conn.preparedMutex.Unlock()
+	return stmt, nil
+}
+
+func (conn *Connection) clearPreparedCache() {
+	conn.preparedMutex.Lock()
+	defer conn.preparedMutex.Unlock()
+	conn.preparedCache = nil
+	conn.preparedGen++
+}
+
 // NewStream creates new Stream object for connection.
 //
 // Since v. 2.10.0, Tarantool supports streams and interactive transactions over them.
@@ -1373,6 +2165,15 @@ func isFeatureInSlice(expected ProtocolFeature, actualSlice []ProtocolFeature) b
 	return false
 }
 
+// WatchOnce returns the current value of a key defined on the server without
+// subscribing to its updates. Response.Data contains the value or it is
+// empty if the key is not defined.
+//
+// Requires Tarantool >= 3.0.
+func (conn *Connection) WatchOnce(key string) (*Response, error) {
+	return conn.Do(NewWatchOnceRequest(key)).Get()
+}
+
 // NewWatcher creates a new Watcher object for the connection.
 //
 // You need to require WatchersFeature to use watchers, see examples for the
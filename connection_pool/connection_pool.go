@@ -30,8 +30,71 @@ var (
 	ErrNoRwInstance      = errors.New("can't find rw instance in pool")
 	ErrNoRoInstance      = errors.New("can't find ro instance in pool")
 	ErrNoHealthyInstance = errors.New("can't find healthy instance in pool")
+	ErrExists            = errors.New("address already exists in pool")
+	ErrNotFound          = errors.New("address not found in pool")
+	ErrClosed            = errors.New("pool is closed")
 )
 
+// LogKind is a kind of an event reported by a pool to a Logger.
+type LogKind int
+
+const (
+	// LogConnectFailed is logged when a connect to an instance failed.
+	LogConnectFailed LogKind = iota + 1
+	// LogStoreFailed is logged when a pool fails to get a role of a new
+	// connection.
+	LogStoreFailed
+	// LogStoreCanceled is logged when ConnectionHandler.Discovered returns
+	// an error.
+	LogStoreCanceled
+	// LogDeactivateFailed is logged when ConnectionHandler.Deactivated
+	// returns an error.
+	LogDeactivateFailed
+	// LogWatchersFailed is logged when a pool fails to initialize watchers
+	// for a new connection.
+	LogWatchersFailed
+	// LogDiscoveryDecodeFailed is logged when a value of the discovery key
+	// could not be decoded. The discovery key is passed instead of
+	// an address.
+	LogDiscoveryDecodeFailed
+	// LogDiscoveryAddFailed is logged when a discovered address could not be
+	// added to a pool.
+	LogDiscoveryAddFailed
+	// LogDiscoveryRemoveFailed is logged when a discovered address could not
+	// be removed from a pool.
+	LogDiscoveryRemoveFailed
+)
+
+// Logger is logger type expected to be passed in options.
+type Logger interface {
+	Report(event LogKind, addr string, err error)
+}
+
+type defaultLogger struct{}
+
+func (d defaultLogger) Report(event LogKind, addr string, err error) {
+	switch event {
+	case LogConnectFailed:
+		log.Printf("tarantool: connect to %s failed: %s\n", addr, err)
+	case LogStoreFailed:
+		log.Printf("tarantool: storing connection to %s failed: %s\n", addr, err)
+	case LogStoreCanceled:
+		log.Printf("tarantool: storing connection to %s canceled: %s\n", addr, err)
+	case LogDeactivateFailed:
+		log.Printf("tarantool: deactivating connection to %s by user failed: %s\n", addr, err)
+	case LogWatchersFailed:
+		log.Printf("tarantool: failed initialize watchers for %s: %s", addr, err)
+	case LogDiscoveryDecodeFailed:
+		log.Printf("tarantool: failed to decode %s value: %s\n", addr, err)
+	case LogDiscoveryAddFailed:
+		log.Printf("tarantool: failed to add %s: %s\n", addr, err)
+	case LogDiscoveryRemoveFailed:
+		log.Printf("tarantool: failed to remove %s: %s\n", addr, err)
+	default:
+		log.Print("tarantool: unexpected pool event ", event, addr, err)
+	}
+}
+
 // ConnectionHandler provides callbacks for components interested in handling
 // changes of connections in a ConnectionPool.
 type ConnectionHandler interface {
@@ -65,6 +128,15 @@ type OptsPool struct {
 	CheckTimeout time.Duration
 	// ConnectionHandler provides an ability to handle connection updates.
 	ConnectionHandler ConnectionHandler
+	// DiscoveryKey is a key of box.broadcast() events with a list of
+	// instance addresses. If it is set, the pool subscribes to the key and
+	// adds or removes instances on each update of the list. An empty list is
+	// ignored. It requires tarantool.WatchersFeature in the connection
+	// options.
+	DiscoveryKey string
+	// Logger is a user specified logger used for error messages. The
+	// messages are written with the standard log package by default.
+	Logger Logger
 }
 
 /*
@@ -98,6 +170,12 @@ type ConnectionPool struct {
 	anyPool          *RoundRobinStrategy
 	poolsMutex       sync.RWMutex
 	watcherContainer watcherContainer
+	// states is a map address -> a connection state of the address checker.
+	states map[string]connState
+
+	discoveryMutex  sync.Mutex
+	discoveryAddrs  []string
+	discoveryNotify chan struct{}
 }
 
 var _ Pooler = (*ConnectionPool)(nil)
@@ -107,6 +185,21 @@ type connState struct {
 	notify chan tarantool.ConnEvent
 	conn   *tarantool.Connection
 	role   Role
+	// stop is closed to stop the address checker.
+	stop chan struct{}
+	// stopped is closed when the address checker is stopped.
+	stopped chan struct{}
+}
+
+func newConnState(addr string) connState {
+	return connState{
+		addr:    addr,
+		notify:  make(chan tarantool.ConnEvent, 10),
+		conn:    nil,
+		role:    UnknownRole,
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
 }
 
 // ConnectWithOpts creates pool for instances with addresses addrs
@@ -118,6 +211,9 @@ func ConnectWithOpts(addrs []string, connOpts tarantool.Opts, opts OptsPool) (co
 	if opts.CheckTimeout <= 0 {
 		return nil, ErrWrongCheckTimeout
 	}
+	if opts.Logger == nil {
+		opts.Logger = defaultLogger{}
+	}
 
 	size := len(addrs)
 	rwPool := NewEmptyRoundRobin(size)
@@ -133,6 +229,7 @@ func ConnectWithOpts(addrs []string, connOpts tarantool.Opts, opts OptsPool) (co
 		rwPool:   rwPool,
 		roPool:   roPool,
 		anyPool:  anyPool,
+		states:   make(map[string]connState),
 	}
 
 	m := make(map[string]bool)
@@ -156,9 +253,17 @@ func ConnectWithOpts(addrs []string, connOpts tarantool.Opts, opts OptsPool) (co
 	connPool.state.set(connectedState)
 
 	for _, s := range states {
+		connPool.states[s.addr] = s
 		go connPool.checker(s)
 	}
 
+	if opts.DiscoveryKey != "" {
+		if err := connPool.discover(opts.DiscoveryKey); err != nil {
+			connPool.Close()
+			return nil, err
+		}
+	}
+
 	return connPool, nil
 }
 
@@ -238,8 +343,62 @@ func (connPool *ConnectionPool) Close() []error {
 	return nil
 }
 
+// Add adds a new instance address to the pool. The pool tries to connect to
+// the instance immediately and reconnects to it in the background as for
+// addresses passed to Connect().
+func (pool *ConnectionPool) Add(addr string) error {
+	pool.poolsMutex.Lock()
+	if pool.state.get() != connectedState {
+		pool.poolsMutex.Unlock()
+		return ErrClosed
+	}
+	if _, ok := pool.states[addr]; ok {
+		pool.poolsMutex.Unlock()
+		return ErrExists
+	}
+
+	s := newConnState(addr)
+	pool.addrs = append(pool.addrs, addr)
+	pool.states[addr] = s
+	pool.poolsMutex.Unlock()
+
+	go pool.checker(pool.tryConnect(s))
+	return nil
+}
+
+// Remove removes an instance address from the pool. It closes a connection
+// to the instance and waits until the connection is deactivated.
+func (pool *ConnectionPool) Remove(addr string) error {
+	pool.poolsMutex.Lock()
+	if pool.state.get() != connectedState {
+		pool.poolsMutex.Unlock()
+		return ErrClosed
+	}
+	s, ok := pool.states[addr]
+	if !ok {
+		pool.poolsMutex.Unlock()
+		return ErrNotFound
+	}
+
+	delete(pool.states, addr)
+	for i, a := range pool.addrs {
+		if a == addr {
+			pool.addrs = append(pool.addrs[:i], pool.addrs[i+1:]...)
+			break
+		}
+	}
+	close(s.stop)
+	pool.poolsMutex.Unlock()
+
+	<-s.stopped
+	return nil
+}
+
 // GetAddrs gets addresses of connections in pool.
 func (connPool *ConnectionPool) GetAddrs() []string {
+	connPool.poolsMutex.RLock()
+	defer connPool.poolsMutex.RUnlock()
+
 	cpy := make([]string, len(connPool.addrs))
 	copy(cpy, connPool.addrs)
 	return cpy
@@ -729,7 +888,8 @@ func (pool *ConnectionPool) NewWatcher(key string,
 // For requests that belong to the only one connection (e.g. Unprepare or ExecutePrepared)
 // the argument of type Mode is unused.
 func (connPool *ConnectionPool) Do(req tarantool.Request, userMode Mode) *tarantool.Future {
-	if connectedReq, ok := req.(tarantool.ConnectedRequest); ok {
+	if connectedReq, ok := req.(tarantool.ConnectedRequest); ok &&
+		connectedReq.Conn() != nil {
 		conn, _ := connPool.getConnectionFromPool(connectedReq.Conn().Addr())
 		if conn == nil {
 			return newErrorFuture(fmt.Errorf("the passed connected request doesn't belong to the current connection or connection pool"))
@@ -839,7 +999,7 @@ func (pool *ConnectionPool) addConnection(addr string,
 		for _, watcher := range watched {
 			watcher.unwatch(conn)
 		}
-		log.Printf("tarantool: failed initialize watchers for %s: %s", addr, err)
+		pool.opts.Logger.Report(LogWatchersFailed, addr, err)
 		return err
 	}
 
@@ -862,8 +1022,7 @@ func (connPool *ConnectionPool) handlerDiscovered(conn *tarantool.Connection,
 	}
 
 	if err != nil {
-		addr := conn.Addr()
-		log.Printf("tarantool: storing connection to %s canceled: %s\n", addr, err)
+		connPool.opts.Logger.Report(LogStoreCanceled, conn.Addr(), err)
 		return false
 	}
 	return true
@@ -877,8 +1036,7 @@ func (connPool *ConnectionPool) handlerDeactivated(conn *tarantool.Connection,
 	}
 
 	if err != nil {
-		addr := conn.Addr()
-		log.Printf("tarantool: deactivating connection to %s by user failed: %s\n", addr, err)
+		connPool.opts.Logger.Report(LogDeactivateFailed, conn.Addr(), err)
 	}
 }
 
@@ -889,23 +1047,18 @@ func (connPool *ConnectionPool) fillPools() ([]connState, bool) {
 	// It is called before checker() goroutines and before closeImpl() may be
 	// called so we don't expect concurrency issues here.
 	for i, addr := range connPool.addrs {
-		states[i] = connState{
-			addr:   addr,
-			notify: make(chan tarantool.ConnEvent, 10),
-			conn:   nil,
-			role:   UnknownRole,
-		}
+		states[i] = newConnState(addr)
 		connOpts := connPool.connOpts
 		connOpts.Notify = states[i].notify
 
 		conn, err := tarantool.Connect(addr, connOpts)
 		if err != nil {
-			log.Printf("tarantool: connect to %s failed: %s\n", addr, err.Error())
+			connPool.opts.Logger.Report(LogConnectFailed, addr, err)
 		} else if conn != nil {
 			role, err := connPool.getConnectionRole(conn)
 			if err != nil {
 				conn.Close()
-				log.Printf("tarantool: storing connection to %s failed: %s\n", addr, err)
+				connPool.opts.Logger.Report(LogStoreFailed, addr, err)
 				continue
 			}
 
@@ -1003,7 +1156,7 @@ func (pool *ConnectionPool) tryConnect(s connState) connState {
 
 		if err != nil {
 			conn.Close()
-			log.Printf("tarantool: storing connection to %s failed: %s\n", s.addr, err)
+			pool.opts.Logger.Report(LogStoreFailed, s.addr, err)
 			return s
 		}
 
@@ -1056,11 +1209,17 @@ func (pool *ConnectionPool) reconnect(s connState) connState {
 func (pool *ConnectionPool) checker(s connState) {
 	timer := time.NewTicker(pool.opts.CheckTimeout)
 	defer timer.Stop()
+	defer close(s.stopped)
 
 	for {
 		select {
 		case <-pool.done:
-			close(s.notify)
+			// The connection could be removed before the pool was closed,
+			// in this case closeImpl() does not know about it.
+			pool.stopChecker(s)
+			return
+		case <-s.stop:
+			pool.stopChecker(s)
 			return
 		case <-s.notify:
 			if s.conn != nil && s.conn.ClosedNow() {
@@ -1090,6 +1249,105 @@ func (pool *ConnectionPool) checker(s connState) {
 	}
 }
 
+// stopChecker deinitializes a connection of a stopped checker.
+func (pool *ConnectionPool) stopChecker(s connState) {
+	close(s.notify)
+	if s.conn == nil {
+		return
+	}
+
+	pool.poolsMutex.Lock()
+	conn := pool.anyPool.GetConnByAddr(s.addr)
+	if conn == s.conn {
+		pool.deleteConnection(s.addr)
+	}
+	pool.poolsMutex.Unlock()
+
+	if conn == s.conn {
+		s.conn.Close()
+		pool.handlerDeactivated(s.conn, s.role)
+	}
+}
+
+// discover subscribes to the discovery key and updates addresses of the pool
+// on events.
+func (pool *ConnectionPool) discover(key string) error {
+	pool.discoveryNotify = make(chan struct{}, 1)
+	_, err := pool.NewWatcher(key, func(event tarantool.WatchEvent) {
+		if event.Value == nil {
+			return
+		}
+
+		var addrs []string
+		if err := event.DecodeValue(&addrs); err != nil {
+			pool.opts.Logger.Report(LogDiscoveryDecodeFailed, event.Key, err)
+			return
+		}
+
+		pool.discoveryMutex.Lock()
+		pool.discoveryAddrs = addrs
+		pool.discoveryMutex.Unlock()
+
+		select {
+		case pool.discoveryNotify <- struct{}{}:
+		default:
+		}
+	}, ANY)
+	if err != nil {
+		return err
+	}
+
+	go pool.discoverer()
+	return nil
+}
+
+// discoverer applies the latest discovered addresses to the pool. Updates are
+// applied outside of watcher callbacks because removing a connection
+// unregisters its watchers.
+func (pool *ConnectionPool) discoverer() {
+	for {
+		select {
+		case <-pool.done:
+			return
+		case <-pool.discoveryNotify:
+			pool.discoveryMutex.Lock()
+			addrs := pool.discoveryAddrs
+			pool.discoveryMutex.Unlock()
+
+			if len(addrs) > 0 {
+				pool.updateAddrs(addrs)
+			}
+		}
+	}
+}
+
+// updateAddrs adds new addresses to the pool and removes missing ones.
+func (pool *ConnectionPool) updateAddrs(addrs []string) {
+	actual := make(map[string]bool, len(addrs))
+	for _, addr := range addrs {
+		actual[addr] = true
+	}
+
+	for _, addr := range pool.GetAddrs() {
+		if !actual[addr] {
+			if err := pool.Remove(addr); err != nil && err != ErrNotFound {
+				pool.opts.Logger.Report(LogDiscoveryRemoveFailed, addr, err)
+			}
+		}
+		delete(actual, addr)
+	}
+
+	for _, addr := range addrs {
+		if !actual[addr] {
+			continue
+		}
+		delete(actual, addr)
+		if err := pool.Add(addr); err != nil && err != ErrExists {
+			pool.opts.Logger.Report(LogDiscoveryAddFailed, addr, err)
+		}
+	}
+}
+
 func (connPool *ConnectionPool) getNextConnection(mode Mode) (*tarantool.Connection, error) {
 
 	switch mode {
@@ -82,6 +82,36 @@ func TestConnSuccessfully(t *testing.T) {
 	require.Nil(t, err)
 }
 
+type testLogger struct {
+	mutex  sync.Mutex
+	events map[string]connection_pool.LogKind
+}
+
+func (l *testLogger) Report(event connection_pool.LogKind, addr string, err error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.events[addr] = event
+}
+
+func TestConnWithLogger(t *testing.T) {
+	logger := &testLogger{events: map[string]connection_pool.LogKind{}}
+	poolOpts := connection_pool.OptsPool{
+		CheckTimeout: 1 * time.Second,
+		Logger:       logger,
+	}
+	connPool, err := connection_pool.ConnectWithOpts([]string{"err", servers[0]},
+		connOpts, poolOpts)
+	require.Nilf(t, err, "failed to connect")
+	require.NotNilf(t, connPool, "conn is nil after Connect")
+	defer connPool.Close()
+
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+	require.Equal(t, map[string]connection_pool.LogKind{
+		"err": connection_pool.LogConnectFailed,
+	}, logger.events)
+}
+
 func TestConnSuccessfullyDuplicates(t *testing.T) {
 	server := servers[0]
 	connPool, err := connection_pool.Connect([]string{server, server, server, server}, connOpts)
@@ -107,6 +137,199 @@ func TestConnSuccessfullyDuplicates(t *testing.T) {
 	require.Equalf(t, []string{server}, addrs, "should be only one address")
 }
 
+func TestAdd(t *testing.T) {
+	connPool, err := connection_pool.Connect([]string{servers[0]}, connOpts)
+	require.Nilf(t, err, "failed to connect")
+	require.NotNilf(t, connPool, "conn is nil after Connect")
+
+	defer connPool.Close()
+
+	err = connPool.Add(servers[1])
+	require.Nil(t, err)
+	require.Equal(t, []string{servers[0], servers[1]}, connPool.GetAddrs())
+
+	args := test_helpers.CheckStatusesArgs{
+		ConnPool:           connPool,
+		Mode:               connection_pool.ANY,
+		Servers:            []string{servers[0], servers[1]},
+		ExpectedPoolStatus: true,
+		ExpectedStatuses: map[string]bool{
+			servers[0]: true,
+			servers[1]: true,
+		},
+	}
+
+	err = test_helpers.Retry(test_helpers.CheckPoolStatuses, args,
+		defaultCountRetry, defaultTimeoutRetry)
+	require.Nil(t, err)
+}
+
+func TestAdd_exists(t *testing.T) {
+	connPool, err := connection_pool.Connect([]string{servers[0]}, connOpts)
+	require.Nilf(t, err, "failed to connect")
+	require.NotNilf(t, connPool, "conn is nil after Connect")
+
+	defer connPool.Close()
+
+	err = connPool.Add(servers[0])
+	require.Equal(t, connection_pool.ErrExists, err)
+}
+
+func TestAdd_closed(t *testing.T) {
+	connPool, err := connection_pool.Connect([]string{servers[0]}, connOpts)
+	require.Nilf(t, err, "failed to connect")
+	require.NotNilf(t, connPool, "conn is nil after Connect")
+
+	connPool.Close()
+
+	err = connPool.Add(servers[1])
+	require.Equal(t, connection_pool.ErrClosed, err)
+}
+
+func TestRemove(t *testing.T) {
+	connPool, err := connection_pool.Connect([]string{servers[0], servers[1]},
+		connOpts)
+	require.Nilf(t, err, "failed to connect")
+	require.NotNilf(t, connPool, "conn is nil after Connect")
+
+	defer connPool.Close()
+
+	err = connPool.Remove(servers[1])
+	require.Nil(t, err)
+	require.Equal(t, []string{servers[0]}, connPool.GetAddrs())
+
+	args := test_helpers.CheckStatusesArgs{
+		ConnPool:           connPool,
+		Mode:               connection_pool.ANY,
+		Servers:            []string{servers[0], servers[1]},
+		ExpectedPoolStatus: true,
+		ExpectedStatuses: map[string]bool{
+			servers[0]: true,
+			servers[1]: false,
+		},
+	}
+
+	err = test_helpers.CheckPoolStatuses(args)
+	require.Nil(t, err)
+}
+
+func TestRemove_notFound(t *testing.T) {
+	connPool, err := connection_pool.Connect([]string{servers[0]}, connOpts)
+	require.Nilf(t, err, "failed to connect")
+	require.NotNilf(t, connPool, "conn is nil after Connect")
+
+	defer connPool.Close()
+
+	err = connPool.Remove(servers[1])
+	require.Equal(t, connection_pool.ErrNotFound, err)
+}
+
+type testRemoveHandler struct {
+	deactivated sync.Map
+}
+
+func (h *testRemoveHandler) Discovered(conn *tarantool.Connection,
+	role connection_pool.Role) error {
+	return nil
+}
+
+func (h *testRemoveHandler) Deactivated(conn *tarantool.Connection,
+	role connection_pool.Role) error {
+	h.deactivated.Store(conn.Addr(), true)
+	return nil
+}
+
+func TestRemove_handler(t *testing.T) {
+	h := &testRemoveHandler{}
+	poolOpts := connection_pool.OptsPool{
+		CheckTimeout:      500 * time.Millisecond,
+		ConnectionHandler: h,
+	}
+	connPool, err := connection_pool.ConnectWithOpts(
+		[]string{servers[0], servers[1]}, connOpts, poolOpts)
+	require.Nilf(t, err, "failed to connect")
+	require.NotNilf(t, connPool, "conn is nil after Connect")
+
+	defer connPool.Close()
+
+	err = connPool.Remove(servers[1])
+	require.Nil(t, err)
+
+	_, ok := h.deactivated.Load(servers[1])
+	require.Truef(t, ok, "%s is not deactivated", servers[1])
+	_, ok = h.deactivated.Load(servers[0])
+	require.Falsef(t, ok, "%s is deactivated", servers[0])
+}
+
+func TestDiscoveryKey(t *testing.T) {
+	test_helpers.SkipIfWatchersUnsupported(t)
+
+	const key = "TestDiscoveryKey"
+	opts := connOpts.Clone()
+	opts.RequiredProtocolInfo.Features = []tarantool.ProtocolFeature{
+		tarantool.WatchersFeature,
+	}
+
+	broadcast := func(addrs []string) {
+		for _, server := range servers[:2] {
+			conn := test_helpers.ConnectWithValidation(t, server, opts)
+			req := tarantool.NewBroadcastRequest(key).Value(addrs)
+			_, err := conn.Do(req).Get()
+			conn.Close()
+			require.Nilf(t, err, "failed to broadcast to %s", server)
+		}
+	}
+
+	poolOpts := connection_pool.OptsPool{
+		CheckTimeout: 500 * time.Millisecond,
+		DiscoveryKey: key,
+	}
+	connPool, err := connection_pool.ConnectWithOpts([]string{servers[0]},
+		opts, poolOpts)
+	require.Nilf(t, err, "failed to connect")
+	require.NotNilf(t, connPool, "conn is nil after Connect")
+
+	defer connPool.Close()
+
+	broadcast([]string{servers[0], servers[1]})
+
+	args := test_helpers.CheckStatusesArgs{
+		ConnPool:           connPool,
+		Mode:               connection_pool.ANY,
+		Servers:            []string{servers[0], servers[1]},
+		ExpectedPoolStatus: true,
+		ExpectedStatuses: map[string]bool{
+			servers[0]: true,
+			servers[1]: true,
+		},
+	}
+	err = test_helpers.Retry(test_helpers.CheckPoolStatuses, args,
+		defaultCountRetry, defaultTimeoutRetry)
+	require.Nil(t, err)
+
+	broadcast([]string{servers[1]})
+
+	args.ExpectedStatuses = map[string]bool{
+		servers[0]: false,
+		servers[1]: true,
+	}
+	err = test_helpers.Retry(test_helpers.CheckPoolStatuses, args,
+		defaultCountRetry, defaultTimeoutRetry)
+	require.Nil(t, err)
+	require.Equal(t, []string{servers[1]}, connPool.GetAddrs())
+}
+
+func TestDiscoveryKey_noWatchersFeature(t *testing.T) {
+	poolOpts := connection_pool.OptsPool{
+		CheckTimeout: 500 * time.Millisecond,
+		DiscoveryKey: "TestDiscoveryKey_noWatchersFeature",
+	}
+	connPool, err := connection_pool.ConnectWithOpts([]string{servers[0]},
+		connOpts, poolOpts)
+	require.Nil(t, connPool)
+	require.NotNil(t, err)
+}
+
 func TestReconnect(t *testing.T) {
 	server := servers[0]
 
@@ -21,6 +21,7 @@ const (
 	IdRequestCode        = 73
 	WatchRequestCode     = 74
 	UnwatchRequestCode   = 75
+	WatchOnceRequestCode = 77
 
 	KeyCode         = 0x00
 	KeySync         = 0x01
@@ -55,8 +56,13 @@ const (
 	KeyEvent        = 0x57
 	KeyEventData    = 0x58
 	KeyTxnIsolation = 0x59
+	KeyIsSync       = 0x61
 	KeyAuthType     = 0x5b
 
+	KeySchemaVersion = 0x05
+	KeySpaceName     = 0x5e
+	KeyIndexName     = 0x5f
+
 	KeyFieldName               = 0x00
 	KeyFieldType               = 0x01
 	KeyFieldColl               = 0x02
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"os/exec"
 	"reflect"
 	"testing"
 	"time"
@@ -1090,6 +1091,161 @@ func TestMPDecode(t *testing.T) {
 	}
 }
 
+func TestTimeTypesDecode(t *testing.T) {
+	tm := time.Date(2022, 5, 6, 7, 8, 9, 0, time.UTC)
+	dt, err := NewDatetime(tm)
+	if err != nil {
+		t.Fatalf("Unable to create Datetime from %s: %s", tm, err)
+	}
+
+	values := []interface{}{
+		tm.Unix(),
+		tm.UnixNano() / int64(time.Millisecond),
+		tm.Format(time.RFC3339),
+		dt,
+	}
+	for _, value := range values {
+		buf, err := marshal(value)
+		if err != nil {
+			t.Fatalf("Marshalling failed: %s", err.Error())
+		}
+
+		var unixTime UnixTime
+		var unixMilliTime UnixMilliTime
+		var rfcTime RFC3339Time
+		for _, v := range []interface{}{&unixTime, &unixMilliTime, &rfcTime} {
+			if err = unmarshal(buf, v); err != nil {
+				t.Fatalf("Unmarshalling of %v into %T failed: %s", value, v, err)
+			}
+		}
+
+		if _, ok := value.(int64); ok {
+			// An integer value depends on the type.
+			if value == tm.Unix() && !unixTime.Equal(tm) {
+				t.Errorf("Unexpected UnixTime %v, expected %v", unixTime, tm)
+			}
+			if value != tm.Unix() && !unixMilliTime.Equal(tm) {
+				t.Errorf("Unexpected UnixMilliTime %v, expected %v", unixMilliTime, tm)
+			}
+			continue
+		}
+		for _, got := range []time.Time{unixTime.Time, unixMilliTime.Time, rfcTime.Time} {
+			if !got.Equal(tm) {
+				t.Errorf("Unexpected time %v decoded from %v, expected %v", got, value, tm)
+			}
+		}
+	}
+}
+
+func TestTimeTypesEncode(t *testing.T) {
+	tm := time.Date(2022, 5, 6, 7, 8, 9, 0, time.UTC)
+
+	var unix, unixMilli int64
+	var rfc string
+	tests := []struct {
+		value    interface{}
+		result   interface{}
+		expected interface{}
+	}{
+		{UnixTime{tm}, &unix, tm.Unix()},
+		{UnixMilliTime{tm}, &unixMilli, tm.UnixNano() / int64(time.Millisecond)},
+		{RFC3339Time{tm}, &rfc, tm.Format(time.RFC3339Nano)},
+	}
+	for _, test := range tests {
+		buf, err := marshal(test.value)
+		if err != nil {
+			t.Fatalf("Marshalling failed: %s", err.Error())
+		}
+		if err = unmarshal(buf, test.result); err != nil {
+			t.Fatalf("Unmarshalling failed: %s", err.Error())
+		}
+		actual := reflect.ValueOf(test.result).Elem().Interface()
+		if actual != test.expected {
+			t.Errorf("Failed to encode %T, actual %v, expected %v",
+				test.value, actual, test.expected)
+		}
+	}
+}
+
+// registerTimeEnv is set for a subprocess of TestRegisterTime. RegisterTime
+// replaces the time.Time codec for the whole program, so the test is executed
+// in a separate process to keep other tests intact.
+const registerTimeEnv = "TEST_DATETIME_REGISTER_TIME"
+
+func TestRegisterTime(t *testing.T) {
+	if os.Getenv(registerTimeEnv) == "" {
+		cmd := exec.Command(os.Args[0], "-test.run=^TestRegisterTime$")
+		cmd.Env = append(os.Environ(), registerTimeEnv+"=1")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("TestRegisterTime failed in a subprocess: %s\n%s", err, out)
+		}
+		return
+	}
+
+	RegisterTime()
+
+	type tuple struct {
+		Time time.Time
+	}
+
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Fatalf("Unable to load location: %s", err)
+	}
+	times := []time.Time{
+		time.Date(2022, 5, 6, 7, 8, 9, 10, time.UTC),
+		time.Date(2022, 5, 6, 7, 8, 9, 10, moscow),
+		time.Date(2022, 5, 6, 7, 8, 9, 10, time.FixedZone("", 3*60*60)),
+		time.Date(2022, 5, 6, 7, 8, 9, 10, time.FixedZone("Custom", -2*60*60)),
+	}
+	for _, tm := range times {
+		buf, err := marshal(tuple{tm})
+		if err != nil {
+			t.Fatalf("Marshalling of %v failed: %s", tm, err)
+		}
+
+		var result tuple
+		if err = unmarshal(buf, &result); err != nil {
+			t.Fatalf("Unmarshalling of %v failed: %s", tm, err)
+		}
+		if !result.Time.Equal(tm) {
+			t.Errorf("Unexpected time %v, expected %v", result.Time, tm)
+		}
+		_, offset := result.Time.Zone()
+		if _, expected := tm.Zone(); offset != expected {
+			t.Errorf("Unexpected offset %d, expected %d", offset, expected)
+		}
+
+		var dts []Datetime
+		if buf, err = marshal([]interface{}{tm}); err != nil {
+			t.Fatalf("Marshalling of %v failed: %s", tm, err)
+		}
+		if err = unmarshal(buf, &dts); err != nil {
+			t.Fatalf("Unmarshalling of %v into Datetime failed: %s", tm, err)
+		}
+		if len(dts) != 1 || !dts[0].ToTime().Equal(tm) {
+			t.Errorf("Unexpected datetimes %v, expected %v", dts, tm)
+		}
+	}
+
+	// An array with the msgpack timestamp extension of
+	// 2022-05-06T07:08:09Z.
+	buf, _ := hex.DecodeString("91d6ff6274c959")
+	var values []interface{}
+	if err = unmarshal(buf, &values); err != nil {
+		t.Logf("The msgpack library does not support timestamps: %s", err)
+		return
+	}
+	var result []time.Time
+	if err = unmarshal(buf, &result); err != nil {
+		t.Fatalf("Unmarshalling of a msgpack timestamp failed: %s", err)
+	}
+	expected := time.Date(2022, 5, 6, 7, 8, 9, 0, time.UTC)
+	if len(result) != 1 || !result[0].Equal(expected) {
+		t.Errorf("Unexpected times %v, expected %v", result, expected)
+	}
+}
+
 func TestUnmarshalMsgpackInvalidLength(t *testing.T) {
 	var v Datetime
 
@@ -1125,6 +1281,11 @@ func TestUnmarshalMsgpackInvalidZone(t *testing.T) {
 // is a separate function, see
 // https://stackoverflow.com/questions/27629380/how-to-exit-a-go-program-honoring-deferred-calls
 func runTestMain(m *testing.M) int {
+	if os.Getenv(registerTimeEnv) != "" {
+		// The subprocess of TestRegisterTime does not need an instance.
+		return m.Run()
+	}
+
 	isLess, err := test_helpers.IsTarantoolVersionLess(2, 10, 0)
 	if err != nil {
 		log.Fatalf("Failed to extract Tarantool version: %s", err)
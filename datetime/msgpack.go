@@ -5,6 +5,7 @@ package datetime
 
 import (
 	"reflect"
+	"time"
 
 	"gopkg.in/vmihailenco/msgpack.v2"
 )
@@ -26,3 +27,8 @@ func init() {
 	msgpack.Register(reflect.TypeOf((*Interval)(nil)).Elem(), encodeInterval, decodeInterval)
 	msgpack.RegisterExt(interval_extId, (*Interval)(nil))
 }
+
+func registerTime(enc func(*encoder, reflect.Value) error,
+	dec func(*decoder, reflect.Value) error) {
+	msgpack.Register(reflect.TypeOf(time.Time{}), enc, dec)
+}
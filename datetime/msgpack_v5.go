@@ -6,6 +6,7 @@ package datetime
 import (
 	"bytes"
 	"reflect"
+	"time"
 
 	"github.com/vmihailenco/msgpack/v5"
 )
@@ -40,3 +41,8 @@ func init() {
 			return decodeInterval(d, v)
 		})
 }
+
+func registerTime(enc func(*encoder, reflect.Value) error,
+	dec func(*decoder, reflect.Value) error) {
+	msgpack.Register(time.Time{}, enc, dec)
+}
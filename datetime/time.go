@@ -0,0 +1,141 @@
+package datetime
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Time types help to decode a time value stored in different formats: as
+// the datetime extension, as an integer number of seconds or milliseconds
+// since Unix Epoch or as an RFC3339 string. A format of a field is chosen by
+// the field type. The type defines how an integer value is decoded and how
+// the value is encoded, other representations are decoded as is.
+//
+// The msgpack library does not support custom struct tag options, so
+// the types are used instead of a tag.
+
+// UnixTime is a time.Time which is encoded as a number of seconds since
+// Unix Epoch. An integer value is decoded as seconds.
+type UnixTime struct {
+	time.Time
+}
+
+// UnixMilliTime is a time.Time which is encoded as a number of milliseconds
+// since Unix Epoch. An integer value is decoded as milliseconds.
+type UnixMilliTime struct {
+	time.Time
+}
+
+// RFC3339Time is a time.Time which is encoded as an RFC3339 string with
+// nanoseconds. An integer value is decoded as seconds.
+type RFC3339Time struct {
+	time.Time
+}
+
+// EncodeMsgpack encodes the time as a number of seconds.
+func (t UnixTime) EncodeMsgpack(e *encoder) error {
+	return encodeInt(e, t.Unix())
+}
+
+// DecodeMsgpack decodes the time from any supported format.
+func (t *UnixTime) DecodeMsgpack(d *decoder) (err error) {
+	t.Time, err = decodeTime(d, time.Second)
+	return err
+}
+
+// EncodeMsgpack encodes the time as a number of milliseconds.
+func (t UnixMilliTime) EncodeMsgpack(e *encoder) error {
+	return encodeInt(e, t.UnixNano()/int64(time.Millisecond))
+}
+
+// DecodeMsgpack decodes the time from any supported format.
+func (t *UnixMilliTime) DecodeMsgpack(d *decoder) (err error) {
+	t.Time, err = decodeTime(d, time.Millisecond)
+	return err
+}
+
+// EncodeMsgpack encodes the time as an RFC3339 string.
+func (t RFC3339Time) EncodeMsgpack(e *encoder) error {
+	return e.EncodeString(t.Format(time.RFC3339Nano))
+}
+
+// DecodeMsgpack decodes the time from any supported format.
+func (t *RFC3339Time) DecodeMsgpack(d *decoder) (err error) {
+	t.Time, err = decodeTime(d, time.Second)
+	return err
+}
+
+// RegisterTime registers a msgpack codec for time.Time values. The values are
+// encoded as the Tarantool datetime extension and the extension is decoded
+// into time.Time fields. A time zone offset is preserved, a location unknown
+// to Tarantool (for example, time.Local) is replaced by a fixed offset.
+//
+// The codec replaces the msgpack library time.Time codec for the whole
+// program, so it is opt-in. It should be called once before any encoding or
+// decoding. A time.Time value passed to Encoder.Encode directly, not as
+// a field or an item, is still encoded by the msgpack library.
+func RegisterTime() {
+	registerTime(encodeTimeValue, decodeTimeValue)
+}
+
+func encodeTimeValue(e *encoder, v reflect.Value) error {
+	tm := v.Interface().(time.Time)
+	if _, ok := timezoneToIndex[tm.Location().String()]; !ok {
+		_, offset := tm.Zone()
+		tm = tm.In(time.FixedZone(NoTimezone, offset))
+	}
+
+	dt, err := NewDatetime(tm)
+	if err != nil {
+		return err
+	}
+	return e.Encode(dt)
+}
+
+func decodeTimeValue(d *decoder, v reflect.Value) error {
+	tm, err := decodeTime(d, time.Second)
+	if err != nil {
+		return err
+	}
+	v.Set(reflect.ValueOf(tm))
+	return nil
+}
+
+// decodeTime decodes a time from the datetime extension, an RFC3339 string
+// or an integer number of units since Unix Epoch.
+func decodeTime(d *decoder, unit time.Duration) (time.Time, error) {
+	v, err := d.DecodeInterface()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	switch v := v.(type) {
+	case nil:
+		return time.Time{}, nil
+	case Datetime:
+		return v.ToTime(), nil
+	case *Datetime:
+		return v.ToTime(), nil
+	case time.Time:
+		// The msgpack timestamp extension.
+		return v, nil
+	case string:
+		return time.Parse(time.RFC3339Nano, v)
+	}
+
+	val := reflect.ValueOf(v)
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return unitsToTime(val.Int(), unit), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return unitsToTime(int64(val.Uint()), unit), nil
+	}
+	return time.Time{}, fmt.Errorf("unsupported time value type %T", v)
+}
+
+// unitsToTime converts a number of units since Unix Epoch to a time.
+func unitsToTime(v int64, unit time.Duration) time.Time {
+	perSecond := int64(time.Second / unit)
+	return time.Unix(v/perSecond, (v%perSecond)*int64(unit))
+}
@@ -3,6 +3,7 @@ package tarantool
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -18,7 +19,33 @@ const (
 
 // Greeting is a message sent by Tarantool on connect.
 type Greeting struct {
+	// Version is the first line of the greeting: Tarantool version and
+	// instance UUID.
 	Version string
+	// Salt is a base64-encoded random salt of the session that is used for
+	// authentication.
+	Salt string
+}
+
+// ServerVersion returns a Tarantool version from the greeting, for example,
+// "2.11.0". It returns an empty string if the greeting has an unexpected
+// format.
+func (g Greeting) ServerVersion() string {
+	fields := strings.Fields(g.Version)
+	if len(fields) < 2 || fields[0] != "Tarantool" {
+		return ""
+	}
+	return fields[1]
+}
+
+// Protocol returns a protocol name from the greeting, for example, "Binary".
+// It returns an empty string if the greeting has an unexpected format.
+func (g Greeting) Protocol() string {
+	fields := strings.Fields(g.Version)
+	if len(fields) < 3 || fields[0] != "Tarantool" {
+		return ""
+	}
+	return strings.Trim(fields[2], "()")
 }
 
 // writeFlusher is the interface that groups the basic Write and Flush methods.
@@ -55,6 +82,9 @@ type Conn interface {
 type DialOpts struct {
 	// DialTimeout is a timeout for an initial network dial.
 	DialTimeout time.Duration
+	// DialFunc is a function to establish a network connection. net.Dial
+	// is used if it is nil.
+	DialFunc func(ctx context.Context, network, address string) (net.Conn, error)
 	// IoTimeout is a timeout per a network read/write.
 	IoTimeout time.Duration
 	// Transport is a connect transport type.
@@ -71,6 +101,9 @@ type DialOpts struct {
 	User string
 	// User password for logging in to Tarantool.
 	Password string
+	// Context cancels the dial and the handshake if it is done. It could
+	// be nil.
+	Context context.Context
 }
 
 // Dialer is the interface that wraps a method to connect to a Tarantool
@@ -92,6 +125,8 @@ type tntConn struct {
 	writer   writeFlusher
 	greeting Greeting
 	protocol ProtocolInfo
+	// dialDuration is a time spent to establish a network connection.
+	dialDuration time.Duration
 }
 
 // TtDialer is a default implementation of the Dialer interface which is
@@ -105,29 +140,76 @@ func (t TtDialer) Dial(address string, opts DialOpts) (Conn, error) {
 	var err error
 	conn := new(tntConn)
 
+	if opts.Context != nil {
+		if err = opts.Context.Err(); err != nil {
+			return nil, fmt.Errorf("failed to dial: %w", err)
+		}
+	}
+
+	start := time.Now()
 	if conn.net, err = dial(address, opts); err != nil {
 		return nil, fmt.Errorf("failed to dial: %w", err)
 	}
+	conn.dialDuration = time.Since(start)
 
 	dc := &DeadlineIO{to: opts.IoTimeout, c: conn.net}
 	conn.reader = bufio.NewReaderSize(dc, 128*1024)
 	conn.writer = bufio.NewWriterSize(dc, 128*1024)
 
+	if opts.Context != nil {
+		stop := closeOnDone(opts.Context, conn.net)
+		err = conn.handshake(opts)
+		if ctxErr := stop(); ctxErr != nil {
+			err = fmt.Errorf("failed to handshake: %w", ctxErr)
+		}
+	} else {
+		err = conn.handshake(opts)
+	}
+	if err != nil {
+		conn.net.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// closeOnDone closes the connection if the context is done before the
+// returned function is called. The function returns an error of the context
+// if the connection has been closed.
+func closeOnDone(ctx context.Context, c net.Conn) func() error {
+	stop := make(chan struct{})
+	result := make(chan error, 1)
+	go func() {
+		select {
+		case <-ctx.Done():
+			c.Close()
+			result <- ctx.Err()
+		case <-stop:
+			result <- nil
+		}
+	}()
+	return func() error {
+		close(stop)
+		return <-result
+	}
+}
+
+// handshake reads the greeting, identifies and authenticates the connection.
+func (conn *tntConn) handshake(opts DialOpts) error {
 	var version, salt string
+	var err error
 	if version, salt, err = readGreeting(conn.reader); err != nil {
-		conn.net.Close()
-		return nil, fmt.Errorf("failed to read greeting: %w", err)
+		return fmt.Errorf("failed to read greeting: %w", err)
 	}
 	conn.greeting.Version = version
+	conn.greeting.Salt = salt
 
 	if conn.protocol, err = identify(conn.writer, conn.reader); err != nil {
-		conn.net.Close()
-		return nil, fmt.Errorf("failed to identify: %w", err)
+		return fmt.Errorf("failed to identify: %w", err)
 	}
 
 	if err = checkProtocolInfo(opts.RequiredProtocol, conn.protocol); err != nil {
-		conn.net.Close()
-		return nil, fmt.Errorf("invalid server protocol: %w", err)
+		return fmt.Errorf("invalid server protocol: %w", err)
 	}
 
 	if opts.User != "" {
@@ -139,14 +221,12 @@ func (t TtDialer) Dial(address string, opts DialOpts) (Conn, error) {
 			}
 		}
 
-		err := authenticate(conn, opts, salt)
-		if err != nil {
-			conn.net.Close()
-			return nil, fmt.Errorf("failed to authenticate: %w", err)
+		if err = authenticate(conn, opts, salt); err != nil {
+			return fmt.Errorf("failed to authenticate: %w", err)
 		}
 	}
 
-	return conn, nil
+	return nil
 }
 
 // Read makes tntConn satisfy the Conn interface.
@@ -200,14 +280,91 @@ func dial(address string, opts DialOpts) (net.Conn, error) {
 	network, address := parseAddress(address)
 	switch opts.Transport {
 	case dialTransportNone:
+		if opts.DialFunc != nil || opts.Context != nil {
+			return dialContext(network, address, opts)
+		}
 		return net.DialTimeout(network, address, opts.DialTimeout)
 	case dialTransportSsl:
+		if network == "unix" {
+			return nil, errors.New("ssl transport is not supported for " +
+				"unix sockets")
+		}
+		if opts.DialFunc != nil || opts.Context != nil {
+			conn, err := dialContext(network, address, opts)
+			if err != nil {
+				return nil, err
+			}
+			return sslClientContext(conn, address, opts)
+		}
 		return sslDialTimeout(network, address, opts.DialTimeout, opts.Ssl)
 	default:
 		return nil, fmt.Errorf("unsupported transport type: %s", opts.Transport)
 	}
 }
 
+// sslClientContext establishes an SSL connection over the connection. The
+// handshake is limited by DialOpts.DialTimeout and DialOpts.Context. The
+// connection is closed on error.
+func sslClientContext(conn net.Conn, address string, opts DialOpts) (net.Conn, error) {
+	var deadline time.Time
+	if opts.DialTimeout > 0 {
+		deadline = time.Now().Add(opts.DialTimeout)
+	}
+	if opts.Context != nil {
+		ctxDeadline, ok := opts.Context.Deadline()
+		if ok && (deadline.IsZero() || ctxDeadline.Before(deadline)) {
+			deadline = ctxDeadline
+		}
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	var sslConn net.Conn
+	var err error
+	if opts.Context != nil {
+		stop := closeOnDone(opts.Context, conn)
+		sslConn, err = sslClient(conn, address, opts.Ssl)
+		if ctxErr := stop(); ctxErr != nil {
+			if err == nil {
+				sslConn.Close()
+			}
+			return nil, ctxErr
+		}
+	} else {
+		sslConn, err = sslClient(conn, address, opts.Ssl)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err = conn.SetDeadline(time.Time{}); err != nil {
+		sslConn.Close()
+		return nil, err
+	}
+	return sslConn, nil
+}
+
+// dialContext connects to a Tarantool instance with DialOpts.DialFunc or
+// net.Dialer and DialOpts.Context.
+func dialContext(network, address string, opts DialOpts) (net.Conn, error) {
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if opts.DialTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.DialTimeout)
+		defer cancel()
+	}
+	if opts.DialFunc != nil {
+		return opts.DialFunc(ctx, network, address)
+	}
+	var dialer net.Dialer
+	return dialer.DialContext(ctx, network, address)
+}
+
 // parseAddress split address into network and address parts.
 func parseAddress(address string) (string, string) {
 	network := "tcp"
@@ -2,6 +2,7 @@ package tarantool_test
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"net"
 	"sync"
@@ -90,6 +91,77 @@ func TestDialer_Dial_passedOpts(t *testing.T) {
 	assert.Equal(t, opts, dialer.opts)
 }
 
+func TestTtDialer_Dial_dialFunc(t *testing.T) {
+	const errMsg = "any msg"
+	var network, address string
+	var deadlineSet bool
+
+	dialer := tarantool.TtDialer{}
+	conn, err := dialer.Dial("unix:/tmp/any.sock", tarantool.DialOpts{
+		DialTimeout: time.Second,
+		DialFunc: func(ctx context.Context, n, a string) (net.Conn, error) {
+			network, address = n, a
+			_, deadlineSet = ctx.Deadline()
+			return nil, errors.New(errMsg)
+		},
+	})
+	assert.Nil(t, conn)
+	assert.ErrorContains(t, err, errMsg)
+	assert.Equal(t, "unix", network)
+	assert.Equal(t, "/tmp/any.sock", address)
+	assert.True(t, deadlineSet)
+}
+
+func TestTtDialer_Dial_sslUnixSocket(t *testing.T) {
+	dialer := tarantool.TtDialer{}
+	conn, err := dialer.Dial("unix:///var/run/tarantool/instance.sock",
+		tarantool.DialOpts{Transport: "ssl"})
+	assert.Nil(t, conn)
+	assert.ErrorContains(t, err, "ssl transport is not supported for unix sockets")
+}
+
+func TestTtDialer_Dial_contextCanceled(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	defer l.Close()
+
+	// The server accepts connections, but does not send a greeting.
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	dialer := tarantool.TtDialer{}
+	conn, err := dialer.Dial(l.Addr().String(), tarantool.DialOpts{
+		DialTimeout: time.Second,
+		Context:     ctx,
+	})
+	assert.Nil(t, conn)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestConnectContext_canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	dialer := &mockPassedDialer{}
+	conn, err := tarantool.ConnectContext(ctx, "127.0.0.1:8080", tarantool.Opts{
+		Dialer:    dialer,
+		Reconnect: time.Second,
+	})
+	assert.Nil(t, conn)
+	assert.NotNil(t, err)
+	assert.Equal(t, ctx, dialer.opts.Context)
+}
+
 type mockIoConn struct {
 	// Sends an event on Read()/Write()/Flush().
 	read, written chan struct{}
@@ -266,6 +338,7 @@ func TestConn_RemoteAddr(t *testing.T) {
 func TestConn_Greeting(t *testing.T) {
 	greeting := tarantool.Greeting{
 		Version: "any",
+		Salt:    "salt",
 	}
 	conn, dialer := dialIo(t, func(conn *mockIoConn) {
 		conn.greeting = greeting
@@ -280,6 +353,25 @@ func TestConn_Greeting(t *testing.T) {
 	assert.Equal(t, 1, dialer.conn.greetingCnt)
 }
 
+func TestGreeting_ServerVersion(t *testing.T) {
+	cases := []struct {
+		version  string
+		server   string
+		protocol string
+	}{
+		{"Tarantool 2.11.0 (Binary) 7a4c2bbd-0e5c-4cdd-9ed5-7d6d7f7bbe7c   \n",
+			"2.11.0", "Binary"},
+		{"Tarantool 1.10.15", "1.10.15", ""},
+		{"any", "", ""},
+		{"", "", ""},
+	}
+	for _, tc := range cases {
+		greeting := tarantool.Greeting{Version: tc.version}
+		assert.Equal(t, tc.server, greeting.ServerVersion())
+		assert.Equal(t, tc.protocol, greeting.Protocol())
+	}
+}
+
 func TestConn_ProtocolInfo(t *testing.T) {
 	info := tarantool.ProtocolInfo{
 		Auth:    tarantool.ChapSha1Auth,
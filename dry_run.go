@@ -0,0 +1,321 @@
+package tarantool
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// DryRunRequest is a request captured by a DryRunConnection.
+type DryRunRequest struct {
+	// Request is the original request object.
+	Request Request
+	// Packet is the encoded IPROTO packet: the length, the header and
+	// the body of the request.
+	Packet []byte
+}
+
+// DryRunConnection is a Connector implementation which encodes requests,
+// but does not send them to a Tarantool instance. Each successfully encoded
+// request is captured and completed with an empty successful response.
+//
+// It helps to test a code which builds requests without a network I/O.
+type DryRunConnection struct {
+	// Schema is used to resolve space and index names. Only numeric
+	// identifiers could be used if it is nil.
+	Schema *Schema
+
+	mutex     sync.Mutex
+	requestId uint32
+	requests  []DryRunRequest
+	closed    bool
+}
+
+var _ = Connector(&DryRunConnection{}) // Check compatibility with connector interface.
+
+// NewDryRunConnection creates a new DryRunConnection. The schema is used to
+// resolve space and index names, it could be nil.
+func NewDryRunConnection(schema *Schema) *DryRunConnection {
+	return &DryRunConnection{Schema: schema}
+}
+
+// Requests returns a copy of the captured requests list.
+func (conn *DryRunConnection) Requests() []DryRunRequest {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+
+	requests := make([]DryRunRequest, len(conn.requests))
+	copy(requests, conn.requests)
+	return requests
+}
+
+// Reset clears the captured requests list.
+func (conn *DryRunConnection) Reset() {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+
+	conn.requests = nil
+}
+
+// Do encodes the request, captures it and returns a future with an empty
+// successful response. The future contains an error if the request could
+// not be encoded.
+func (conn *DryRunConnection) Do(req Request) *Future {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+
+	fut := NewFuture()
+	if conn.closed {
+		fut.SetError(ClientError{ErrConnectionClosed, "using closed connection"})
+		return fut
+	}
+
+	conn.requestId++
+	var buf smallWBuf
+	if err := pack(&buf, newEncoder(&buf), conn.requestId, req,
+		ignoreStreamId, conn.Schema); err != nil {
+		fut.SetError(err)
+		return fut
+	}
+	conn.requests = append(conn.requests, DryRunRequest{
+		Request: req,
+		Packet:  buf.b,
+	})
+
+	fut.SetResponse(&Response{RequestId: conn.requestId, Code: OkCode})
+	return fut
+}
+
+// ConnectedNow reports if the connection is not closed.
+func (conn *DryRunConnection) ConnectedNow() bool {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+
+	return !conn.closed
+}
+
+// Close closes the connection. All subsequent requests will fail.
+func (conn *DryRunConnection) Close() error {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+
+	conn.closed = true
+	return nil
+}
+
+// ConfiguredTimeout always returns zero for the DryRunConnection.
+func (conn *DryRunConnection) ConfiguredTimeout() time.Duration {
+	return 0
+}
+
+// Ping encodes and captures a ping request.
+func (conn *DryRunConnection) Ping() (resp *Response, err error) {
+	return conn.Do(NewPingRequest()).Get()
+}
+
+// Select encodes and captures a select request.
+func (conn *DryRunConnection) Select(space, index interface{}, offset, limit, iterator uint32, key interface{}) (resp *Response, err error) {
+	return conn.SelectAsync(space, index, offset, limit, iterator, key).Get()
+}
+
+// Insert encodes and captures an insert request.
+func (conn *DryRunConnection) Insert(space interface{}, tuple interface{}) (resp *Response, err error) {
+	return conn.InsertAsync(space, tuple).Get()
+}
+
+// Replace encodes and captures a replace request.
+func (conn *DryRunConnection) Replace(space interface{}, tuple interface{}) (resp *Response, err error) {
+	return conn.ReplaceAsync(space, tuple).Get()
+}
+
+// Delete encodes and captures a delete request.
+func (conn *DryRunConnection) Delete(space, index interface{}, key interface{}) (resp *Response, err error) {
+	return conn.DeleteAsync(space, index, key).Get()
+}
+
+// Update encodes and captures an update request.
+func (conn *DryRunConnection) Update(space, index interface{}, key, ops interface{}) (resp *Response, err error) {
+	return conn.UpdateAsync(space, index, key, ops).Get()
+}
+
+// Upsert encodes and captures an upsert request.
+func (conn *DryRunConnection) Upsert(space interface{}, tuple, ops interface{}) (resp *Response, err error) {
+	return conn.UpsertAsync(space, tuple, ops).Get()
+}
+
+// Call encodes and captures a call request.
+func (conn *DryRunConnection) Call(functionName string, args interface{}) (resp *Response, err error) {
+	return conn.CallAsync(functionName, args).Get()
+}
+
+// Call16 encodes and captures a call16 request.
+func (conn *DryRunConnection) Call16(functionName string, args interface{}) (resp *Response, err error) {
+	return conn.Call16Async(functionName, args).Get()
+}
+
+// Call17 encodes and captures a call17 request.
+func (conn *DryRunConnection) Call17(functionName string, args interface{}) (resp *Response, err error) {
+	return conn.Call17Async(functionName, args).Get()
+}
+
+// Eval encodes and captures an eval request.
+func (conn *DryRunConnection) Eval(expr string, args interface{}) (resp *Response, err error) {
+	return conn.EvalAsync(expr, args).Get()
+}
+
+// Execute encodes and captures an execute request.
+func (conn *DryRunConnection) Execute(expr string, args interface{}) (resp *Response, err error) {
+	return conn.ExecuteAsync(expr, args).Get()
+}
+
+// GetTyped encodes and captures a select request. The result is not
+// changed.
+func (conn *DryRunConnection) GetTyped(space, index interface{}, key interface{}, result interface{}) (err error) {
+	return conn.SelectAsync(space, index, 0, 1, IterEq, key).GetTyped(result)
+}
+
+// SelectTyped encodes and captures a select request. The result is not
+// changed.
+func (conn *DryRunConnection) SelectTyped(space, index interface{}, offset, limit, iterator uint32, key interface{}, result interface{}) (err error) {
+	return conn.SelectAsync(space, index, offset, limit, iterator, key).GetTyped(result)
+}
+
+// InsertTyped encodes and captures an insert request. The result is not
+// changed.
+func (conn *DryRunConnection) InsertTyped(space interface{}, tuple interface{}, result interface{}) (err error) {
+	return conn.InsertAsync(space, tuple).GetTyped(result)
+}
+
+// ReplaceTyped encodes and captures a replace request. The result is not
+// changed.
+func (conn *DryRunConnection) ReplaceTyped(space interface{}, tuple interface{}, result interface{}) (err error) {
+	return conn.ReplaceAsync(space, tuple).GetTyped(result)
+}
+
+// DeleteTyped encodes and captures a delete request. The result is not
+// changed.
+func (conn *DryRunConnection) DeleteTyped(space, index interface{}, key interface{}, result interface{}) (err error) {
+	return conn.DeleteAsync(space, index, key).GetTyped(result)
+}
+
+// UpdateTyped encodes and captures an update request. The result is not
+// changed.
+func (conn *DryRunConnection) UpdateTyped(space, index interface{}, key, ops interface{}, result interface{}) (err error) {
+	return conn.UpdateAsync(space, index, key, ops).GetTyped(result)
+}
+
+// CallTyped encodes and captures a call request. The result is not
+// changed.
+func (conn *DryRunConnection) CallTyped(functionName string, args interface{}, result interface{}) (err error) {
+	return conn.CallAsync(functionName, args).GetTyped(result)
+}
+
+// Call16Typed encodes and captures a call16 request. The result is not
+// changed.
+func (conn *DryRunConnection) Call16Typed(functionName string, args interface{}, result interface{}) (err error) {
+	return conn.Call16Async(functionName, args).GetTyped(result)
+}
+
+// Call17Typed encodes and captures a call17 request. The result is not
+// changed.
+func (conn *DryRunConnection) Call17Typed(functionName string, args interface{}, result interface{}) (err error) {
+	return conn.Call17Async(functionName, args).GetTyped(result)
+}
+
+// EvalTyped encodes and captures an eval request. The result is not
+// changed.
+func (conn *DryRunConnection) EvalTyped(expr string, args interface{}, result interface{}) (err error) {
+	return conn.EvalAsync(expr, args).GetTyped(result)
+}
+
+// ExecuteTyped encodes and captures an execute request. The result is not
+// changed.
+func (conn *DryRunConnection) ExecuteTyped(expr string, args interface{}, result interface{}) (SQLInfo, []ColumnMetaData, error) {
+	err := conn.ExecuteAsync(expr, args).GetTyped(result)
+	return SQLInfo{}, nil, err
+}
+
+// SelectAsync encodes and captures a select request.
+func (conn *DryRunConnection) SelectAsync(space, index interface{}, offset, limit, iterator uint32, key interface{}) *Future {
+	req := NewSelectRequest(space).
+		Index(index).
+		Offset(offset).
+		Limit(limit).
+		Iterator(iterator).
+		Key(key)
+	return conn.Do(req)
+}
+
+// InsertAsync encodes and captures an insert request.
+func (conn *DryRunConnection) InsertAsync(space interface{}, tuple interface{}) *Future {
+	return conn.Do(NewInsertRequest(space).Tuple(tuple))
+}
+
+// ReplaceAsync encodes and captures a replace request.
+func (conn *DryRunConnection) ReplaceAsync(space interface{}, tuple interface{}) *Future {
+	return conn.Do(NewReplaceRequest(space).Tuple(tuple))
+}
+
+// DeleteAsync encodes and captures a delete request.
+func (conn *DryRunConnection) DeleteAsync(space, index interface{}, key interface{}) *Future {
+	return conn.Do(NewDeleteRequest(space).Index(index).Key(key))
+}
+
+// UpdateAsync encodes and captures an update request.
+func (conn *DryRunConnection) UpdateAsync(space, index interface{}, key, ops interface{}) *Future {
+	req := NewUpdateRequest(space).Index(index).Key(key)
+	req.ops = ops
+	return conn.Do(req)
+}
+
+// UpsertAsync encodes and captures an upsert request.
+func (conn *DryRunConnection) UpsertAsync(space interface{}, tuple interface{}, ops interface{}) *Future {
+	req := NewUpsertRequest(space).Tuple(tuple)
+	req.ops = ops
+	return conn.Do(req)
+}
+
+// CallAsync encodes and captures a call request.
+func (conn *DryRunConnection) CallAsync(functionName string, args interface{}) *Future {
+	return conn.Do(NewCallRequest(functionName).Args(args))
+}
+
+// Call16Async encodes and captures a call16 request.
+func (conn *DryRunConnection) Call16Async(functionName string, args interface{}) *Future {
+	return conn.Do(NewCall16Request(functionName).Args(args))
+}
+
+// Call17Async encodes and captures a call17 request.
+func (conn *DryRunConnection) Call17Async(functionName string, args interface{}) *Future {
+	return conn.Do(NewCall17Request(functionName).Args(args))
+}
+
+// EvalAsync encodes and captures an eval request.
+func (conn *DryRunConnection) EvalAsync(expr string, args interface{}) *Future {
+	return conn.Do(NewEvalRequest(expr).Args(args))
+}
+
+// ExecuteAsync encodes and captures an execute request.
+func (conn *DryRunConnection) ExecuteAsync(expr string, args interface{}) *Future {
+	return conn.Do(NewExecuteRequest(expr).Args(args))
+}
+
+// NewPrepared is not supported by the DryRunConnection because there is no
+// statement to prepare on a Tarantool side. Use Do with a PrepareRequest
+// to check the request encoding.
+func (conn *DryRunConnection) NewPrepared(expr string) (*Prepared, error) {
+	return nil, errors.New("NewPrepared is not supported by DryRunConnection")
+}
+
+// NewStream is not supported by the DryRunConnection because a Stream
+// requires a Connection.
+func (conn *DryRunConnection) NewStream() (*Stream, error) {
+	return nil, errors.New("NewStream is not supported by DryRunConnection")
+}
+
+// NewWatcher is not supported by the DryRunConnection because there are
+// no events without a Tarantool instance.
+func (conn *DryRunConnection) NewWatcher(key string,
+	callback WatchCallback) (Watcher, error) {
+	return nil, errors.New("NewWatcher is not supported by DryRunConnection")
+}
@@ -0,0 +1,97 @@
+package tarantool_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/tarantool/go-tarantool"
+	"github.com/tarantool/go-tarantool/test_helpers"
+)
+
+func TestDryRunConnection_Do(t *testing.T) {
+	conn := NewDryRunConnection(nil)
+	defer conn.Close()
+
+	req := NewInsertRequest(validSpace).Tuple([]interface{}{uint(1), "test"})
+	resp, err := conn.Do(req).Get()
+	require.Nil(t, err)
+	require.NotNil(t, resp)
+	require.Equal(t, uint32(OkCode), resp.Code)
+
+	requests := conn.Requests()
+	require.Equal(t, 1, len(requests))
+	require.Equal(t, req, requests[0].Request)
+
+	body, err := test_helpers.ExtractRequestBody(req, &resolver, NewEncoder)
+	require.Nil(t, err)
+	require.Truef(t, bytes.HasSuffix(requests[0].Packet, body),
+		"packet %v does not contain the request body %v", requests[0].Packet, body)
+
+	conn.Reset()
+	require.Equal(t, 0, len(conn.Requests()))
+}
+
+func TestDryRunConnection_Methods(t *testing.T) {
+	conn := NewDryRunConnection(nil)
+	defer conn.Close()
+
+	_, err := conn.Ping()
+	require.Nil(t, err)
+	_, err = conn.Select(validSpace, validIndex, 0, 1, IterEq, []interface{}{uint(1)})
+	require.Nil(t, err)
+	_, err = conn.Replace(validSpace, []interface{}{uint(1)})
+	require.Nil(t, err)
+	var result []interface{}
+	err = conn.Call17Typed("func", []interface{}{}, &result)
+	require.Nil(t, err)
+	<-conn.EvalAsync("return 1", []interface{}{}).WaitChan()
+
+	requests := conn.Requests()
+	require.Equal(t, 5, len(requests))
+	require.Equal(t, int32(PingRequestCode), requests[0].Request.Code())
+	require.Equal(t, int32(SelectRequestCode), requests[1].Request.Code())
+	require.Equal(t, int32(ReplaceRequestCode), requests[2].Request.Code())
+	require.Equal(t, int32(Call17RequestCode), requests[3].Request.Code())
+	require.Equal(t, int32(EvalRequestCode), requests[4].Request.Code())
+}
+
+func TestDryRunConnection_EncodeError(t *testing.T) {
+	conn := NewDryRunConnection(nil)
+	defer conn.Close()
+
+	_, err := conn.Insert("space_name", []interface{}{uint(1)})
+	require.NotNil(t, err)
+	require.Equal(t, 0, len(conn.Requests()))
+}
+
+func TestDryRunConnection_Close(t *testing.T) {
+	conn := NewDryRunConnection(nil)
+	require.True(t, conn.ConnectedNow())
+	require.Nil(t, conn.Close())
+	require.False(t, conn.ConnectedNow())
+
+	_, err := conn.Ping()
+	require.NotNil(t, err)
+	require.Equal(t, 0, len(conn.Requests()))
+}
+
+func TestDryRunConnection_SchemaVersion(t *testing.T) {
+	conn := NewDryRunConnection(nil)
+	defer conn.Close()
+
+	_, err := conn.Do(NewSchemaVersionRequest(NewPingRequest(), 42)).Get()
+	require.Nil(t, err)
+	req := NewUrgentRequest(NewSchemaVersionRequest(NewPingRequest(), 42))
+	_, err = conn.Do(req).Get()
+	require.Nil(t, err)
+
+	requests := conn.Requests()
+	require.Equal(t, 2, len(requests))
+	for _, request := range requests {
+		require.Truef(t, bytes.Contains(request.Packet,
+			[]byte{KeySchemaVersion, 0xcf, 0, 0, 0, 0, 0, 0, 0, 42}),
+			"packet %v does not contain the schema version", request.Packet)
+	}
+}
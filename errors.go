@@ -1,6 +1,9 @@
 package tarantool
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 // Error is wrapper around error returned by Tarantool.
 type Error struct {
@@ -39,23 +42,75 @@ func (clierr ClientError) Error() string {
 // - request is timeouted
 //
 // - request is aborted due to rate limit
+//
+// - request is rejected due to server graceful shutdown, the connection
+// will be reestablished if Opts.Reconnect is set
+//
+// - request is rejected due to an open circuit breaker
 func (clierr ClientError) Temporary() bool {
 	switch clierr.Code {
-	case ErrConnectionNotReady, ErrTimeouted, ErrRateLimited:
+	case ErrConnectionNotReady, ErrTimeouted, ErrRateLimited,
+		ErrConnectionShutdown, ErrCircuitOpen:
 		return true
 	default:
 		return false
 	}
 }
 
+// IsError returns true if the err or any error in its chain is an Error or
+// a ClientError with the code.
+//
+// It allows to branch on error codes instead of error messages:
+//
+//	if tarantool.IsError(err, tarantool.ErrTupleFound) {
+//		// Duplicate key.
+//	}
+func IsError(err error, code uint32) bool {
+	var tnterr Error
+	if errors.As(err, &tnterr) {
+		return tnterr.Code == code
+	}
+	var clierr ClientError
+	if errors.As(err, &clierr) {
+		return clierr.Code == code
+	}
+	return false
+}
+
+// BatchError is returned by batch requests if some of requests failed.
+type BatchError struct {
+	// Errors contains an error for each request of the batch. It is nil
+	// for a successful or not sent request.
+	Errors []error
+}
+
+// Error converts a BatchError to a string.
+func (batcherr BatchError) Error() string {
+	var failed int
+	var first error
+	for _, err := range batcherr.Errors {
+		if err != nil {
+			if first == nil {
+				first = err
+			}
+			failed++
+		}
+	}
+	return fmt.Sprintf("%d of %d requests failed, first error: %s",
+		failed, len(batcherr.Errors), first)
+}
+
 // Tarantool client error codes.
 const (
-	ErrConnectionNotReady = 0x4000 + iota
-	ErrConnectionClosed   = 0x4000 + iota
-	ErrProtocolError      = 0x4000 + iota
-	ErrTimeouted          = 0x4000 + iota
-	ErrRateLimited        = 0x4000 + iota
-	ErrConnectionShutdown = 0x4000 + iota
+	ErrConnectionNotReady   = 0x4000 + iota
+	ErrConnectionClosed     = 0x4000 + iota
+	ErrProtocolError        = 0x4000 + iota
+	ErrTimeouted            = 0x4000 + iota
+	ErrRateLimited          = 0x4000 + iota
+	ErrConnectionShutdown   = 0x4000 + iota
+	ErrRetryBudgetExhausted = 0x4000 + iota
+	ErrRequestTooLarge      = 0x4000 + iota
+	ErrCircuitOpen          = 0x4000 + iota
 )
 
 // Tarantool server error codes.
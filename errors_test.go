@@ -0,0 +1,55 @@
+package tarantool_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/tarantool/go-tarantool"
+)
+
+func TestIsError(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		code     uint32
+		expected bool
+	}{
+		{"nil", nil, ErrTupleFound, false},
+		{"other", errors.New("any"), ErrTupleFound, false},
+		{"error", Error{Code: ErrTupleFound}, ErrTupleFound, true},
+		{"error_other_code", Error{Code: ErrTupleNotFound}, ErrTupleFound, false},
+		{"wrapped_error", fmt.Errorf("wrap: %w", Error{Code: ErrTupleFound}),
+			ErrTupleFound, true},
+		{"client_error", ClientError{Code: ErrTimeouted}, ErrTimeouted, true},
+		{"client_error_other_code", ClientError{Code: ErrTimeouted},
+			ErrConnectionClosed, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, IsError(tc.err, tc.code))
+		})
+	}
+}
+
+func TestClientError_Temporary(t *testing.T) {
+	cases := []struct {
+		code     uint32
+		expected bool
+	}{
+		{ErrConnectionNotReady, true},
+		{ErrConnectionClosed, false},
+		{ErrProtocolError, false},
+		{ErrTimeouted, true},
+		{ErrRateLimited, true},
+		{ErrConnectionShutdown, true},
+	}
+
+	for _, tc := range cases {
+		err := ClientError{Code: tc.code}
+		require.Equalf(t, tc.expected, err.Temporary(), "code 0x%x", tc.code)
+	}
+}
@@ -15,6 +15,10 @@ func SslCreateContext(opts SslOpts) (ctx interface{}, err error) {
 	return sslCreateContext(opts)
 }
 
+func ToSnakeCase(name string) string {
+	return toSnakeCase(name)
+}
+
 // RefImplPingBody is reference implementation for filling of a ping
 // request's body.
 func RefImplPingBody(enc *encoder) error {
@@ -25,37 +29,40 @@ func RefImplPingBody(enc *encoder) error {
 // request's body.
 func RefImplSelectBody(enc *encoder, space, index, offset, limit, iterator uint32,
 	key, after interface{}, fetchPos bool) error {
-	return fillSelect(enc, space, index, offset, limit, iterator, key, after, fetchPos)
+	return fillSelect(enc, spaceEncoder{Id: space, IsId: true},
+		indexEncoder{Id: index, IsId: true}, offset, limit, iterator, key, after, fetchPos)
 }
 
 // RefImplInsertBody is reference implementation for filling of an insert
 // request's body.
 func RefImplInsertBody(enc *encoder, space uint32, tuple interface{}) error {
-	return fillInsert(enc, space, tuple)
+	return fillInsert(enc, spaceEncoder{Id: space, IsId: true}, tuple)
 }
 
 // RefImplReplaceBody is reference implementation for filling of a replace
 // request's body.
 func RefImplReplaceBody(enc *encoder, space uint32, tuple interface{}) error {
-	return fillInsert(enc, space, tuple)
+	return fillInsert(enc, spaceEncoder{Id: space, IsId: true}, tuple)
 }
 
 // RefImplDeleteBody is reference implementation for filling of a delete
 // request's body.
 func RefImplDeleteBody(enc *encoder, space, index uint32, key interface{}) error {
-	return fillDelete(enc, space, index, key)
+	return fillDelete(enc, spaceEncoder{Id: space, IsId: true},
+		indexEncoder{Id: index, IsId: true}, key)
 }
 
 // RefImplUpdateBody is reference implementation for filling of an update
 // request's body.
 func RefImplUpdateBody(enc *encoder, space, index uint32, key, ops interface{}) error {
-	return fillUpdate(enc, space, index, key, ops)
+	return fillUpdate(enc, spaceEncoder{Id: space, IsId: true},
+		indexEncoder{Id: index, IsId: true}, key, ops)
 }
 
 // RefImplUpsertBody is reference implementation for filling of an upsert
 // request's body.
 func RefImplUpsertBody(enc *encoder, space uint32, tuple, ops interface{}) error {
-	return fillUpsert(enc, space, tuple, ops)
+	return fillUpsert(enc, spaceEncoder{Id: space, IsId: true}, tuple, ops)
 }
 
 // RefImplCallBody is reference implementation for filling of a call or call17
@@ -96,14 +103,15 @@ func RefImplUnprepareBody(enc *encoder, stmt Prepared) error {
 
 // RefImplBeginBody is reference implementation for filling of an begin
 // request's body.
-func RefImplBeginBody(enc *encoder, txnIsolation TxnIsolationLevel, timeout time.Duration) error {
-	return fillBegin(enc, txnIsolation, timeout)
+func RefImplBeginBody(enc *encoder, txnIsolation TxnIsolationLevel, timeout time.Duration,
+	isSync bool) error {
+	return fillBegin(enc, txnIsolation, timeout, isSync)
 }
 
 // RefImplCommitBody is reference implementation for filling of an commit
 // request's body.
-func RefImplCommitBody(enc *encoder) error {
-	return fillCommit(enc)
+func RefImplCommitBody(enc *encoder, isSync bool) error {
+	return fillCommit(enc, isSync)
 }
 
 // RefImplRollbackBody is reference implementation for filling of an rollback
@@ -121,3 +129,8 @@ func RefImplIdBody(enc *encoder, protocolInfo ProtocolInfo) error {
 func NewEncoder(w io.Writer) *encoder {
 	return newEncoder(w)
 }
+
+// IsUrgentRequest returns true if the request is sent before queued requests.
+func IsUrgentRequest(req Request) bool {
+	return isUrgentRequest(req)
+}
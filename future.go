@@ -1,12 +1,43 @@
 package tarantool
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
+// RequestTimings contains durations of the request execution phases.
+type RequestTimings struct {
+	// Queue is a time spent by the request in the client queue: from the
+	// request sending to the moment the connection writer starts to write
+	// it to the network.
+	Queue time.Duration
+	// Wire is a time from the request writing to the response receiving.
+	// It contains the network round trip and the server processing time.
+	// It is zero if the request was not written.
+	Wire time.Duration
+}
+
 // Future is a handle for asynchronous request.
 type Future struct {
+	// writtenAt is a time since epoch when the request was written.
+	writtenAt int64
+	// sentAt is a time since epoch when the request was sent.
+	sentAt time.Duration
+	// doneAt is a time since epoch when the future was finished.
+	doneAt time.Duration
+	// req is the request of the future, it is used to retry the request.
+	req Request
+	// conn is the connection the request is sent with, it is nil for
+	// a future created with NewFuture.
+	conn *Connection
+	// internal is true for a request of the connection which bypasses
+	// Opts.MaxInFlight and the circuit breaker.
+	internal bool
+
 	requestId uint32
 	next      *Future
 	timeout   time.Duration
@@ -16,6 +47,8 @@ type Future struct {
 	err       error
 	ready     chan struct{}
 	done      chan struct{}
+	// callbacks are called after the future is finished, see Then.
+	callbacks []func(*Response, error)
 }
 
 func (fut *Future) wait() {
@@ -150,9 +183,11 @@ func (fut *Future) SetResponse(resp *Response) {
 		return
 	}
 	fut.resp = resp
+	fut.doneAt = time.Since(epoch)
 
 	close(fut.ready)
 	close(fut.done)
+	fut.runCallbacks()
 }
 
 // SetError sets an error for the future and finishes the future.
@@ -164,9 +199,49 @@ func (fut *Future) SetError(err error) {
 		return
 	}
 	fut.err = err
+	fut.doneAt = time.Since(epoch)
 
 	close(fut.ready)
 	close(fut.done)
+	fut.runCallbacks()
+}
+
+// Then registers a callback which is called with a result of Get() when
+// the future is finished. Callbacks are called in a separate goroutine in
+// the order of registration, so they do not block the connection reader.
+// If the future is already finished, the callback is called immediately in
+// a new goroutine.
+//
+// Get and GetTyped should not be called concurrently with callbacks because
+// the response body is decoded by callbacks.
+func (fut *Future) Then(callback func(*Response, error)) *Future {
+	fut.mutex.Lock()
+	if !fut.isDone() {
+		fut.callbacks = append(fut.callbacks, callback)
+		fut.mutex.Unlock()
+		return fut
+	}
+	fut.mutex.Unlock()
+
+	go callback(fut.Get())
+	return fut
+}
+
+// runCallbacks starts a goroutine to call registered callbacks. It should
+// be called under the mutex after the future is finished.
+func (fut *Future) runCallbacks() {
+	if len(fut.callbacks) == 0 {
+		return
+	}
+	callbacks := fut.callbacks
+	fut.callbacks = nil
+
+	go func() {
+		resp, err := fut.Get()
+		for _, callback := range callbacks {
+			callback(resp, err)
+		}
+	}()
 }
 
 // Get waits for Future to be filled and returns Response and error.
@@ -200,6 +275,75 @@ func (fut *Future) GetTyped(result interface{}) error {
 	return err
 }
 
+// GetContext waits for Future to be filled or the context to be done and
+// returns Response and error as Get does. An error is returned if the
+// context is done before the response arrives. The request is not
+// cancelled in this case, use Request.Context() for the purpose.
+func (fut *Future) GetContext(ctx context.Context) (*Response, error) {
+	if err := fut.waitContext(ctx); err != nil {
+		return nil, err
+	}
+	return fut.Get()
+}
+
+// GetTypedContext waits for Future to be filled or the context to be done
+// and decodes the result as GetTyped does. An error is returned if the
+// context is done before the response arrives. The request is not
+// cancelled in this case, use Request.Context() for the purpose.
+func (fut *Future) GetTypedContext(ctx context.Context, result interface{}) error {
+	if err := fut.waitContext(ctx); err != nil {
+		return err
+	}
+	return fut.GetTyped(result)
+}
+
+// GetWithTimeout waits at most the timeout for Future to be filled and
+// returns Response and error as Get does. ClientError with ErrTimeouted code
+// is returned if the response does not arrive in time. The request is not
+// cancelled in this case.
+func (fut *Future) GetWithTimeout(timeout time.Duration) (*Response, error) {
+	if err := fut.waitTimeout(timeout); err != nil {
+		return nil, err
+	}
+	return fut.Get()
+}
+
+// GetTypedWithTimeout waits at most the timeout for Future to be filled and
+// decodes the result as GetTyped does. ClientError with ErrTimeouted code is
+// returned if the response does not arrive in time. The request is not
+// cancelled in this case.
+func (fut *Future) GetTypedWithTimeout(timeout time.Duration,
+	result interface{}) error {
+	if err := fut.waitTimeout(timeout); err != nil {
+		return err
+	}
+	return fut.GetTyped(result)
+}
+
+func (fut *Future) waitTimeout(timeout time.Duration) error {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-fut.WaitChan():
+		return nil
+	case <-timer.C:
+		return ClientError{
+			Code: ErrTimeouted,
+			Msg:  fmt.Sprintf("client timeout for request %d", fut.requestId),
+		}
+	}
+}
+
+func (fut *Future) waitContext(ctx context.Context) error {
+	select {
+	case <-fut.WaitChan():
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("context is done: %w", ctx.Err())
+	}
+}
+
 // GetIterator returns an iterator for iterating through push messages
 // and a response. Push messages and the response will contain deserialized
 // result in Data field as for the Get() function.
@@ -235,3 +379,70 @@ func (fut *Future) Err() error {
 	fut.wait()
 	return fut.err
 }
+
+// RequestId returns the IPROTO_SYNC value of the request. It matches
+// Response.RequestId of the response. It is zero if the request was not
+// sent by a Connection.
+func (fut *Future) RequestId() uint32 {
+	return fut.requestId
+}
+
+// Timings returns durations of the request execution phases. It waits for
+// future to be set. The timings are measured only for requests sent by
+// a Connection, otherwise the result is empty.
+func (fut *Future) Timings() RequestTimings {
+	fut.wait()
+
+	var timings RequestTimings
+	if fut.sentAt == 0 {
+		return timings
+	}
+
+	writtenAt := time.Duration(atomic.LoadInt64(&fut.writtenAt))
+	if writtenAt == 0 || writtenAt > fut.doneAt {
+		timings.Queue = fut.doneAt - fut.sentAt
+	} else {
+		timings.Queue = writtenAt - fut.sentAt
+		timings.Wire = fut.doneAt - writtenAt
+	}
+	return timings
+}
+
+// Discard stops waiting for the response of the request: the future is
+// finished with an error and the response is dropped silently when it
+// comes. Tarantool has no way to cancel a request, so the request is still
+// executed by the server. It does nothing if the future is already
+// finished.
+func (fut *Future) Discard() {
+	if fut.conn != nil && !fut.isDone() {
+		fut.conn.discardFuture(fut)
+	}
+}
+
+// Retry sends the request of the future again on the connection and returns
+// a new future. The connection could be different from the original one.
+// It could be used to retry a request after a temporary error without
+// building the request again.
+//
+// The retry fails fast with ErrRetryBudgetExhausted error if the connection
+// retry budget is exhausted, see Opts.RetryBudget.
+//
+// Only futures created by a Connection hold the request and support retries.
+// The returned future contains an error for other futures. A request of
+// a stream is sent without the stream.
+func (fut *Future) Retry(conn *Connection) *Future {
+	if fut.req == nil {
+		retryFut := NewFuture()
+		retryFut.SetError(errors.New("the future does not hold a request to retry"))
+		return retryFut
+	}
+	if conn.retryBudget != nil && !conn.retryBudget.take() {
+		retryFut := NewFuture()
+		retryFut.SetError(ClientError{
+			ErrRetryBudgetExhausted,
+			"retry budget is exhausted",
+		})
+		return retryFut
+	}
+	return conn.Do(fut.req)
+}
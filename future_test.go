@@ -1,6 +1,7 @@
 package tarantool_test
 
 import (
+	"context"
 	"errors"
 	"sync"
 	"testing"
@@ -256,3 +257,137 @@ func TestFutureSetStateRaceCondition(t *testing.T) {
 	// It may be false-positive, but very rarely - it's ok for such very
 	// simple race conditions tests.
 }
+
+func TestFutureTimingsNotSent(t *testing.T) {
+	fut := NewFuture()
+	fut.SetResponse(&Response{})
+
+	if timings := fut.Timings(); timings != (RequestTimings{}) {
+		t.Errorf("An unexpected timings %v for a not sent request", timings)
+	}
+}
+
+func TestFutureGetContext(t *testing.T) {
+	resp := &Response{}
+	fut := NewFuture()
+	fut.SetResponse(resp)
+
+	got, err := fut.GetContext(context.Background())
+	if err != nil {
+		t.Errorf("An unexpected error: %q", err.Error())
+	}
+	if got != resp {
+		t.Errorf("An unexpected response %v, expected %v", got, resp)
+	}
+}
+
+func TestFutureGetContextDone(t *testing.T) {
+	fut := NewFuture()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	resp, err := fut.GetContext(ctx)
+	if err == nil || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("An unexpected error %v, expected context deadline", err)
+	}
+	if resp != nil {
+		t.Errorf("An unexpected response %v", resp)
+	}
+
+	var result []interface{}
+	err = fut.GetTypedContext(ctx, &result)
+	if err == nil || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("An unexpected error %v, expected context deadline", err)
+	}
+}
+
+func TestFutureGetWithTimeout(t *testing.T) {
+	fut := NewFuture()
+
+	resp, err := fut.GetWithTimeout(10 * time.Millisecond)
+	if clientErr, ok := err.(ClientError); !ok || clientErr.Code != ErrTimeouted {
+		t.Errorf("An unexpected error %v, expected timeout", err)
+	}
+	if resp != nil {
+		t.Errorf("An unexpected response %v", resp)
+	}
+
+	expected := &Response{}
+	fut.SetResponse(expected)
+	resp, err = fut.GetWithTimeout(10 * time.Millisecond)
+	if err != nil {
+		t.Errorf("An unexpected error: %q", err.Error())
+	}
+	if resp != expected {
+		t.Errorf("An unexpected response %v, expected %v", resp, expected)
+	}
+}
+
+func TestFutureRetryWithoutRequest(t *testing.T) {
+	fut := NewFuture()
+	fut.SetError(errors.New("any error"))
+
+	if err := fut.Retry(&Connection{}).Err(); err == nil {
+		t.Errorf("An error expected for a future without a request")
+	}
+}
+
+func TestFutureThen(t *testing.T) {
+	expected := &Response{}
+	fut := NewFuture()
+
+	var calls []int
+	done := make(chan struct{})
+	fut.Then(func(resp *Response, err error) {
+		if err != nil || resp != expected {
+			t.Errorf("An unexpected result %v, %v", resp, err)
+		}
+		calls = append(calls, 1)
+	}).Then(func(resp *Response, err error) {
+		calls = append(calls, 2)
+		close(done)
+	})
+
+	fut.SetResponse(expected)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("Callbacks are not called")
+	}
+	if len(calls) != 2 || calls[0] != 1 || calls[1] != 2 {
+		t.Errorf("An unexpected calls order %v", calls)
+	}
+
+	finished := make(chan error, 1)
+	fut.Then(func(resp *Response, err error) {
+		finished <- err
+	})
+	select {
+	case err := <-finished:
+		if err != nil {
+			t.Errorf("An unexpected error: %q", err.Error())
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("A callback of a finished future is not called")
+	}
+}
+
+func TestFutureThenError(t *testing.T) {
+	expected := errors.New("any error")
+	fut := NewFuture()
+
+	finished := make(chan error, 1)
+	fut.Then(func(resp *Response, err error) {
+		finished <- err
+	})
+	fut.SetError(expected)
+
+	select {
+	case err := <-finished:
+		if err != expected {
+			t.Errorf("An unexpected error %v, expected %v", err, expected)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("A callback is not called")
+	}
+}
@@ -0,0 +1,59 @@
+package tarantool
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+)
+
+// GzipBytes is utility type for storing large binary values compressed. It
+// is encoded as a MP_BIN with the gzip compressed value and it is
+// decompressed on decoding into a GzipBytes value, for example, into a
+// struct field:
+//
+//	tuple := []interface{}{1, GzipBytes(body)}
+//	_, err := conn.Do(NewReplaceRequest("docs").Tuple(tuple)).Get()
+//
+// The compression is done on the client side only. Tarantool does not
+// decompress such values and stores them as opaque varbinary fields, so
+// the values could not be indexed or processed by Lua code without
+// decompression. Tarantool Enterprise field compression is configured in
+// a space format and it is transparent for the connector.
+type GzipBytes []byte
+
+// EncodeMsgpack encodes the compressed value as a MP_BIN.
+func (b GzipBytes) EncodeMsgpack(e *encoder) error {
+	if b == nil {
+		return e.EncodeNil()
+	}
+
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(b); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return e.EncodeBytes(buf.Bytes())
+}
+
+// DecodeMsgpack decodes a MP_BIN and decompresses it.
+func (b *GzipBytes) DecodeMsgpack(d *decoder) error {
+	data, err := d.DecodeBytes()
+	if err != nil {
+		return err
+	}
+	if data == nil {
+		*b = nil
+		return nil
+	}
+
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	*b, err = ioutil.ReadAll(r)
+	return err
+}
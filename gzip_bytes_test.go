@@ -0,0 +1,47 @@
+package tarantool_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/tarantool/go-tarantool"
+)
+
+func TestGzipBytes(t *testing.T) {
+	value := bytes.Repeat([]byte("compressed value "), 100)
+
+	data, err := marshal(GzipBytes(value))
+	require.Nil(t, err)
+	require.Less(t, len(data), len(value))
+
+	var compressed []byte
+	err = unmarshal(data, &compressed)
+	require.Nil(t, err)
+	require.NotEqual(t, value, compressed)
+
+	var decoded GzipBytes
+	err = unmarshal(data, &decoded)
+	require.Nil(t, err)
+	require.Equal(t, GzipBytes(value), decoded)
+}
+
+func TestGzipBytes_nil(t *testing.T) {
+	data, err := marshal(GzipBytes(nil))
+	require.Nil(t, err)
+
+	decoded := GzipBytes("value")
+	err = unmarshal(data, &decoded)
+	require.Nil(t, err)
+	require.Nil(t, decoded)
+}
+
+func TestGzipBytes_invalid(t *testing.T) {
+	data, err := marshal([]byte("not compressed"))
+	require.Nil(t, err)
+
+	var decoded GzipBytes
+	err = unmarshal(data, &decoded)
+	require.NotNil(t, err)
+}
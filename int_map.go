@@ -0,0 +1,69 @@
+package tarantool
+
+import (
+	"sort"
+)
+
+// IntMap is utility type for encoding a map with integer keys. Keys are
+// always encoded as MP_INT/MP_UINT values, so Tarantool could access the
+// values by integer keys:
+//
+//	args := []interface{}{IntMap{1: "one", 2: "two"}}
+//	_, err := conn.Do(NewCallRequest("func").Args(args)).Get()
+//
+// Keys are encoded in ascending order. Values are decoded as
+// interface{} values.
+type IntMap map[int64]interface{}
+
+// EncodeMsgpack encodes the map with integer keys.
+func (m IntMap) EncodeMsgpack(e *encoder) error {
+	if m == nil {
+		return e.EncodeNil()
+	}
+
+	keys := make([]int64, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+
+	if err := e.EncodeMapLen(len(keys)); err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err := encodeInt(e, key); err != nil {
+			return err
+		}
+		if err := e.Encode(m[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecodeMsgpack decodes a map with integer keys.
+func (m *IntMap) DecodeMsgpack(d *decoder) error {
+	l, err := d.DecodeMapLen()
+	if err != nil {
+		return err
+	}
+	if l < 0 {
+		*m = nil
+		return nil
+	}
+
+	decoded := make(IntMap, l)
+	for i := 0; i < l; i++ {
+		key, err := d.DecodeInt64()
+		if err != nil {
+			return err
+		}
+		if decoded[key], err = d.DecodeInterface(); err != nil {
+			return err
+		}
+	}
+	*m = decoded
+	return nil
+}
@@ -0,0 +1,57 @@
+package tarantool_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/tarantool/go-tarantool"
+	"github.com/tarantool/go-tarantool/test_helpers"
+)
+
+func TestIntMap(t *testing.T) {
+	data, err := marshal(IntMap{2: "two", -1: "minus one", 1: "one"})
+	require.Nil(t, err)
+
+	expected := []byte{0x83,
+		0xff, 0xa9, 'm', 'i', 'n', 'u', 's', ' ', 'o', 'n', 'e',
+		0x01, 0xa3, 'o', 'n', 'e',
+		0x02, 0xa3, 't', 'w', 'o',
+	}
+	require.Equal(t, expected, data)
+
+	var decoded IntMap
+	err = unmarshal(data, &decoded)
+	require.Nil(t, err)
+	require.Equal(t, IntMap{2: "two", -1: "minus one", 1: "one"}, decoded)
+}
+
+func TestIntMap_nil(t *testing.T) {
+	data, err := marshal(IntMap(nil))
+	require.Nil(t, err)
+
+	decoded := IntMap{1: "one"}
+	err = unmarshal(data, &decoded)
+	require.Nil(t, err)
+	require.Nil(t, decoded)
+}
+
+func TestIntMap_stringKeys(t *testing.T) {
+	data, err := marshal(map[string]interface{}{"1": "one"})
+	require.Nil(t, err)
+
+	var decoded IntMap
+	err = unmarshal(data, &decoded)
+	require.NotNil(t, err)
+}
+
+func TestConnection_IntMap(t *testing.T) {
+	conn := test_helpers.ConnectWithValidation(t, server, opts)
+	defer conn.Close()
+
+	var res []interface{}
+	err := conn.EvalTyped("return type(next(...))",
+		[]interface{}{IntMap{1: "one"}}, &res)
+	require.Nil(t, err)
+	require.Equal(t, []interface{}{"number"}, res)
+}
@@ -44,11 +44,20 @@ func msgpackIsArray(code byte) bool {
 		msgpcode.IsFixedArray(code)
 }
 
+func msgpackIsBin(code byte) bool {
+	return code == msgpcode.Bin8 || code == msgpcode.Bin16 ||
+		code == msgpcode.Bin32
+}
+
 func msgpackIsString(code byte) bool {
 	return msgpcode.IsFixedString(code) || code == msgpcode.Str8 ||
 		code == msgpcode.Str16 || code == msgpcode.Str32
 }
 
+func msgpackIsNil(code byte) bool {
+	return code == msgpcode.Nil
+}
+
 func init() {
 	msgpack.RegisterExt(errorExtID, &BoxError{})
 }
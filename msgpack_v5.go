@@ -5,6 +5,9 @@ package tarantool
 
 import (
 	"io"
+	"math"
+	"reflect"
+	"strings"
 
 	"github.com/vmihailenco/msgpack/v5"
 	"github.com/vmihailenco/msgpack/v5/msgpcode"
@@ -48,11 +51,63 @@ func msgpackIsArray(code byte) bool {
 		msgpcode.IsFixedArray(code)
 }
 
+func msgpackIsBin(code byte) bool {
+	return code == msgpcode.Bin8 || code == msgpcode.Bin16 ||
+		code == msgpcode.Bin32
+}
+
 func msgpackIsString(code byte) bool {
 	return msgpcode.IsFixedString(code) || code == msgpcode.Str8 ||
 		code == msgpcode.Str16 || code == msgpcode.Str32
 }
 
+func msgpackIsNil(code byte) bool {
+	return code == msgpcode.Nil
+}
+
 func init() {
 	msgpack.RegisterExt(errorExtID, (*BoxError)(nil))
+	registerRawExts()
+}
+
+// knownExtIDs contains MP_EXT types supported by the connector packages:
+// decimal, uuid, error, datetime and interval. It contains the msgpack
+// timestamp type too.
+var knownExtIDs = map[int8]bool{
+	-1:         true,
+	1:          true,
+	2:          true,
+	errorExtID: true,
+	4:          true,
+	6:          true,
+}
+
+// registerRawExts registers RawExt decoders for MP_EXT types unknown to
+// the connector. It skips types already registered with the msgpack library,
+// the decoders are replaced by further registrations.
+func registerRawExts() {
+	for code := math.MinInt8; code <= math.MaxInt8; code++ {
+		extID := int8(code)
+		if knownExtIDs[extID] || extRegistered(extID) {
+			continue
+		}
+		msgpack.RegisterExtDecoder(extID, RawExt{},
+			func(d *msgpack.Decoder, v reflect.Value, extLen int) error {
+				data := make([]byte, extLen)
+				if _, err := io.ReadFull(d.Buffered(), data); err != nil {
+					return err
+				}
+				v.Set(reflect.ValueOf(RawExt{Code: extID, Data: data}))
+				return nil
+			})
+	}
+}
+
+// extRegistered returns true if a decoder of the MP_EXT type is registered
+// with the msgpack library. The library does not provide a lookup, so it
+// tries to decode an empty value of the type.
+func extRegistered(extID int8) bool {
+	var v interface{}
+	err := msgpack.Unmarshal([]byte{msgpcode.FixExt1, byte(extID), 0}, &v)
+	return err == nil || !strings.HasPrefix(err.Error(), "msgpack: unknown ext id")
 }
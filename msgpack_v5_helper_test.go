@@ -11,6 +11,10 @@ import (
 type encoder = msgpack.Encoder
 type decoder = msgpack.Decoder
 
+// rawExtDecodingSupported is true if unknown MP_EXT types are decoded
+// as RawExt.
+const rawExtDecodingSupported = true
+
 func encodeUint(e *encoder, v uint64) error {
 	return e.EncodeUint(v)
 }
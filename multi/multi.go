@@ -8,12 +8,21 @@
 // - Get the address list from the server and reconfigure it for use in
 // MultiConnection.
 //
+// - Balance stateless read requests between connected instances.
+//
 // Since: 1.5
 package multi
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"math/rand"
+	"net"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -30,8 +39,34 @@ var (
 	ErrEmptyAddrs        = errors.New("addrs should not be empty")
 	ErrWrongCheckTimeout = errors.New("wrong check timeout, must be greater than 0")
 	ErrNoConnection      = errors.New("no active connections")
+	ErrReadOnly          = errors.New("write requests are forbidden in read-only mode")
+	ErrNoRwInstance      = errors.New("can't find rw instance")
+	ErrNoRoInstance      = errors.New("can't find ro instance")
 )
 
+// EachError is returned by ConnectionMulti.EachConnection if the function
+// failed for some of connections.
+type EachError struct {
+	// Errors contains an error for each failed connection by its address.
+	Errors map[string]error
+}
+
+// Error converts an EachError to a string.
+func (eacherr EachError) Error() string {
+	addrs := make([]string, 0, len(eacherr.Errors))
+	for addr := range eacherr.Errors {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+
+	msgs := make([]string, len(addrs))
+	for i, addr := range addrs {
+		msgs[i] = fmt.Sprintf("%s: %s", addr, eacherr.Errors[addr])
+	}
+	return fmt.Sprintf("failed for %d connections: %s", len(addrs),
+		strings.Join(msgs, ", "))
+}
+
 func indexOf(sstring string, data []string) int {
 	for i, v := range data {
 		if sstring == v {
@@ -41,6 +76,49 @@ func indexOf(sstring string, data []string) int {
 	return -1
 }
 
+// uniqueAddrs returns the addresses without duplicates in the original
+// order.
+func uniqueAddrs(addrs []string) []string {
+	unique := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		if indexOf(addr, unique) < 0 {
+			unique = append(unique, addr)
+		}
+	}
+	return unique
+}
+
+// validateAddr checks that the address is a host:port pair or a path to
+// a unix socket.
+func validateAddr(addr string) error {
+	for _, prefix := range []string{"unix://", "unix:", "unix/:"} {
+		if strings.HasPrefix(addr, prefix) {
+			if len(addr) == len(prefix) {
+				return fmt.Errorf("invalid address %q: empty unix socket path", addr)
+			}
+			return nil
+		}
+	}
+	if strings.HasPrefix(addr, ".") || strings.HasPrefix(addr, "/") {
+		return nil
+	}
+
+	hostPort := addr
+	if strings.HasPrefix(hostPort, "tcp://") {
+		hostPort = hostPort[len("tcp://"):]
+	} else if strings.HasPrefix(hostPort, "tcp:") {
+		hostPort = hostPort[len("tcp:"):]
+	}
+	_, port, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		return fmt.Errorf("invalid address %q: %s", addr, err)
+	}
+	if p, err := strconv.Atoi(port); err != nil || p <= 0 || p > 65535 {
+		return fmt.Errorf("invalid address %q: invalid port %q", addr, port)
+	}
+	return nil
+}
+
 // ConnectionMulti is a handle with connections to a number of Tarantool instances.
 //
 // It is created and configured with Connect function, and could not be
@@ -48,6 +126,7 @@ func indexOf(sstring string, data []string) int {
 type ConnectionMulti struct {
 	addrs    []string
 	connOpts tarantool.Opts
+	nodeOpts map[string]tarantool.Opts
 	opts     OptsMulti
 
 	mutex    sync.RWMutex
@@ -56,6 +135,16 @@ type ConnectionMulti struct {
 	control  chan struct{}
 	pool     map[string]*tarantool.Connection
 	fallback *tarantool.Connection
+	// readOnly contains cached box.info.ro values of connected instances.
+	readOnly map[string]bool
+	// absences contains numbers of consecutive discovery responses without
+	// an address.
+	absences map[string]uint
+
+	hedged     uint64
+	hedgedWins uint64
+	// balancerIdx is a counter for the RoundRobin balancing.
+	balancerIdx uint32
 }
 
 var _ = tarantool.Connector(&ConnectionMulti{}) // Check compatibility with connector interface.
@@ -70,6 +159,74 @@ type OptsMulti struct {
 	// Time interval to ask the server for an updated address list (works
 	// if NodesGetFunctionName is set).
 	ClusterDiscoveryTime time.Duration
+	// DiscoveryEvictThreshold is a number of consecutive discovery responses
+	// without an address after that the address is removed. It prevents
+	// churning of connections if the responses flap. An address is removed
+	// after the first absence if it is zero or one.
+	DiscoveryEvictThreshold uint
+	// ConnectTimeout limits a time of the initial connect to the
+	// addresses. The addresses are connected in parallel and Connect
+	// returns after the timeout if at least one instance is connected.
+	// Connections established later are added to the pool. Connect waits
+	// for all addresses if it is zero.
+	ConnectTimeout time.Duration
+	// HedgeReads enables hedging of Select, SelectTyped and SelectAsync
+	// requests: if a response from the current connection is not received
+	// within HedgeDelay, the request is sent to one more healthy instance
+	// and the first successful response wins. The slower request is
+	// cancelled. It trades extra load for lower tail latency.
+	HedgeReads bool
+	// HedgeDelay is a time to wait for a response before a hedged request
+	// is sent. Both requests are sent at once if it is zero.
+	HedgeDelay time.Duration
+	// PerNodeOpts contains connection options for specific addresses. The
+	// non-zero fields override the shared connection options, Notify is
+	// always ignored and Ssl is used only if Transport is set. The shared
+	// options are used for unlisted addresses.
+	PerNodeOpts map[string]tarantool.Opts
+	// Balancing is a policy to select a connection for stateless read
+	// requests: ping, select, call and eval requests passed to Do and
+	// wrappers like Select, Call, Eval. Write and SQL requests, prepared
+	// statements and streams always use the first healthy connection,
+	// use DoMode to route them. FirstHealthy is used by default.
+	Balancing Balancing
+	// ReadOnly forbids Insert, Replace, Delete, Update and Upsert requests.
+	// The requests fail with ErrReadOnly without sending. Calls, evals and
+	// SQL requests are not checked.
+	ReadOnly bool
+}
+
+// Balancing is a policy to select a connection for a request.
+type Balancing int
+
+const (
+	// FirstHealthy selects the first connected instance in the addresses
+	// list. It is a failover-only mode.
+	FirstHealthy Balancing = iota
+	// RoundRobin selects connected instances in turn.
+	RoundRobin
+	// Random selects a random connected instance.
+	Random
+)
+
+// Mode is a mode of an instance selection for DoMode.
+type Mode uint32
+
+const (
+	ANY      Mode = iota // The request can be executed on any instance.
+	RW                   // The request can only be executed on a writable instance.
+	RO                   // The request can only be executed on a read-only instance.
+	PreferRW             // A writable instance is preferred, otherwise a read-only one.
+	PreferRO             // A read-only instance is preferred, otherwise a writable one.
+)
+
+// HedgeStats contains statistics of hedged requests.
+type HedgeStats struct {
+	// Hedged is a number of requests that were sent to a second instance.
+	Hedged uint64
+	// HedgedWins is a number of hedged requests where the second instance
+	// responded first.
+	HedgedWins uint64
 }
 
 // Connect creates and configures new ConnectionMulti with multiconnection options.
@@ -77,6 +234,12 @@ func ConnectWithOpts(addrs []string, connOpts tarantool.Opts, opts OptsMulti) (c
 	if len(addrs) == 0 {
 		return nil, ErrEmptyAddrs
 	}
+	for _, addr := range addrs {
+		if err := validateAddr(addr); err != nil {
+			return nil, err
+		}
+	}
+	addrs = uniqueAddrs(addrs)
 	if opts.CheckTimeout <= 0 {
 		return nil, ErrWrongCheckTimeout
 	}
@@ -89,16 +252,23 @@ func ConnectWithOpts(addrs []string, connOpts tarantool.Opts, opts OptsMulti) (c
 	connMulti = &ConnectionMulti{
 		addrs:    addrs,
 		connOpts: connOpts.Clone(),
+		nodeOpts: make(map[string]tarantool.Opts, len(opts.PerNodeOpts)),
 		opts:     opts,
 		notify:   notify,
 		control:  make(chan struct{}),
 		pool:     make(map[string]*tarantool.Connection),
+		readOnly: make(map[string]bool),
+		absences: make(map[string]uint),
+	}
+	for addr, nodeOpts := range opts.PerNodeOpts {
+		connMulti.nodeOpts[addr] = mergeOpts(connMulti.connOpts, nodeOpts)
 	}
 	somebodyAlive, _ := connMulti.warmUp()
 	if !somebodyAlive {
 		connMulti.Close()
 		return nil, ErrNoConnection
 	}
+	connMulti.updateReadOnly()
 	go connMulti.checker()
 
 	return connMulti, nil
@@ -112,25 +282,136 @@ func Connect(addrs []string, connOpts tarantool.Opts) (connMulti *ConnectionMult
 	return ConnectWithOpts(addrs, connOpts, opts)
 }
 
+// mergeOpts returns a copy of the base options with non-zero fields
+// overridden by the node options. Notify is always taken from the base
+// options. Pass, RLimitAction and Ssl are taken together with User,
+// RateLimit and Transport.
+func mergeOpts(base, node tarantool.Opts) tarantool.Opts {
+	opts := base.Clone()
+	optsVal := reflect.ValueOf(&opts).Elem()
+	nodeVal := reflect.ValueOf(node)
+	for i := 0; i < nodeVal.NumField(); i++ {
+		switch nodeVal.Type().Field(i).Name {
+		case "Notify", "Pass", "RLimitAction", "Ssl":
+			continue
+		}
+		if field := nodeVal.Field(i); !field.IsZero() {
+			optsVal.Field(i).Set(field)
+		}
+	}
+	if node.User != "" {
+		opts.Pass = node.Pass
+	}
+	if node.RateLimit != 0 {
+		opts.RLimitAction = node.RLimitAction
+	}
+	if node.Transport != "" {
+		opts.Ssl = node.Ssl
+	}
+	if node.RequiredProtocolInfo.Version != 0 ||
+		len(node.RequiredProtocolInfo.Features) != 0 {
+		opts.RequiredProtocolInfo = node.RequiredProtocolInfo.Clone()
+	}
+	return opts
+}
+
+// getConnOpts returns connection options for the address. The connection
+// label is derived from the address: it is the address itself or
+// "label/address" if the label is set in the options.
+func (connMulti *ConnectionMulti) getConnOpts(addr string) tarantool.Opts {
+	opts, ok := connMulti.nodeOpts[addr]
+	if !ok {
+		opts = connMulti.connOpts
+	}
+	if opts.Label == "" {
+		opts.Label = addr
+	} else {
+		opts.Label = opts.Label + "/" + addr
+	}
+	return opts
+}
+
+// warmUpResult is a result of a connect to an address on warm up.
+type warmUpResult struct {
+	i    int
+	addr string
+	conn *tarantool.Connection
+	err  error
+}
+
 func (connMulti *ConnectionMulti) warmUp() (somebodyAlive bool, errs []error) {
 	errs = make([]error, len(connMulti.addrs))
+	results := make(chan warmUpResult, len(connMulti.addrs))
 
 	for i, addr := range connMulti.addrs {
-		conn, err := tarantool.Connect(addr, connMulti.connOpts)
-		errs[i] = err
-		if conn != nil && err == nil {
-			if connMulti.fallback == nil {
-				connMulti.fallback = conn
-			}
-			connMulti.pool[addr] = conn
-			if conn.ConnectedNow() {
-				somebodyAlive = true
+		go func(i int, addr string) {
+			conn, err := tarantool.Connect(addr, connMulti.getConnOpts(addr))
+			results <- warmUpResult{i: i, addr: addr, conn: conn, err: err}
+		}(i, addr)
+	}
+
+	var deadline <-chan time.Time
+	if connMulti.opts.ConnectTimeout > 0 {
+		timer := time.NewTimer(connMulti.opts.ConnectTimeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+
+	expired := false
+	pending := len(connMulti.addrs)
+	for pending > 0 && !(expired && somebodyAlive) {
+		select {
+		case res := <-results:
+			pending--
+			errs[res.i] = res.err
+			if res.conn != nil && res.err == nil {
+				connMulti.setConnectionToPool(res.addr, res.conn)
+				if res.conn.ConnectedNow() {
+					somebodyAlive = true
+				}
 			}
+		case <-deadline:
+			expired = true
+			deadline = nil
 		}
 	}
+	if pending > 0 {
+		go connMulti.warmUpLate(results, pending)
+	}
+
+	connMulti.mutex.Lock()
+	for _, addr := range connMulti.addrs {
+		if conn, ok := connMulti.pool[addr]; ok {
+			connMulti.fallback = conn
+			break
+		}
+	}
+	connMulti.mutex.Unlock()
 	return
 }
 
+// warmUpLate adds connections established after OptsMulti.ConnectTimeout
+// to the pool.
+func (connMulti *ConnectionMulti) warmUpLate(results <-chan warmUpResult, pending int) {
+	for ; pending > 0; pending-- {
+		res := <-results
+		if res.conn == nil || res.err != nil {
+			continue
+		}
+
+		connMulti.mutex.Lock()
+		_, exists := connMulti.pool[res.addr]
+		if exists || connMulti.getState() == connClosed ||
+			indexOf(res.addr, connMulti.addrs) < 0 {
+			connMulti.mutex.Unlock()
+			res.conn.Close()
+			continue
+		}
+		connMulti.pool[res.addr] = res.conn
+		connMulti.mutex.Unlock()
+	}
+}
+
 func (connMulti *ConnectionMulti) getState() uint32 {
 	return atomic.LoadUint32(&connMulti.state)
 }
@@ -175,7 +456,7 @@ func (connMulti *ConnectionMulti) checker() {
 				if _, ok := connMulti.getConnectionFromPool(addr); !ok {
 					continue
 				}
-				conn, _ := tarantool.Connect(addr, connMulti.connOpts)
+				conn, _ := tarantool.Connect(addr, connMulti.getConnOpts(addr))
 				if conn != nil {
 					connMulti.setConnectionToPool(addr, conn)
 				} else {
@@ -192,49 +473,166 @@ func (connMulti *ConnectionMulti) checker() {
 				continue
 			}
 			if len(resp) > 0 && len(resp[0]) > 0 {
-				addrs := resp[0]
-				// Fill pool with new connections.
-				for _, v := range addrs {
-					if indexOf(v, connMulti.addrs) < 0 {
-						conn, _ := tarantool.Connect(v, connMulti.connOpts)
-						if conn != nil {
-							connMulti.setConnectionToPool(v, conn)
-						}
-					}
-				}
-				// Clear pool from obsolete connections.
-				for _, v := range connMulti.addrs {
-					if indexOf(v, addrs) < 0 {
-						con, ok := connMulti.getConnectionFromPool(v)
-						if con != nil && ok {
-							con.Close()
-						}
-						connMulti.deleteConnectionFromPool(v)
-					}
-				}
-				connMulti.mutex.Lock()
-				connMulti.addrs = addrs
-				connMulti.mutex.Unlock()
+				connMulti.updateAddrs(resp[0])
 			}
 		case <-timer.C:
-			for _, addr := range connMulti.addrs {
-				if connMulti.getState() == connClosed {
-					return
-				}
-				if conn, ok := connMulti.getConnectionFromPool(addr); ok {
-					if !conn.ClosedNow() {
-						continue
-					}
-				}
-				conn, _ := tarantool.Connect(addr, connMulti.connOpts)
-				if conn != nil {
-					connMulti.setConnectionToPool(addr, conn)
-				}
+			connMulti.reconnectClosed()
+			if connMulti.getState() == connClosed {
+				return
 			}
+			connMulti.updateReadOnly()
 		}
 	}
 }
 
+// reconnectWorkers is a maximum number of concurrent connects or read-only
+// status requests on a check.
+const reconnectWorkers = 16
+
+// reconnectClosed connects to the addresses without an open connection
+// concurrently and stores the new connections to the pool.
+func (connMulti *ConnectionMulti) reconnectClosed() {
+	connMulti.mutex.RLock()
+	closed := make([]string, 0)
+	for _, addr := range connMulti.addrs {
+		if conn, ok := connMulti.pool[addr]; ok && !conn.ClosedNow() {
+			continue
+		}
+		closed = append(closed, addr)
+	}
+	connMulti.mutex.RUnlock()
+
+	conns := make([]*tarantool.Connection, len(closed))
+	workers := make(chan struct{}, reconnectWorkers)
+	var wg sync.WaitGroup
+	for i, addr := range closed {
+		wg.Add(1)
+		workers <- struct{}{}
+		go func(i int, addr string) {
+			defer wg.Done()
+			defer func() { <-workers }()
+			conns[i], _ = tarantool.Connect(addr, connMulti.getConnOpts(addr))
+		}(i, addr)
+	}
+	wg.Wait()
+
+	connMulti.mutex.Lock()
+	defer connMulti.mutex.Unlock()
+	for i, conn := range conns {
+		if conn == nil {
+			continue
+		}
+		if connMulti.getState() == connClosed {
+			conn.Close()
+			continue
+		}
+		connMulti.pool[closed[i]] = conn
+	}
+}
+
+// updateAddrs merges the discovered addresses into the pool. An address
+// absent from the discovered list is removed after
+// OptsMulti.DiscoveryEvictThreshold consecutive absences.
+func (connMulti *ConnectionMulti) updateAddrs(discovered []string) {
+	addrs := make([]string, 0, len(discovered))
+	for _, addr := range uniqueAddrs(discovered) {
+		if validateAddr(addr) == nil {
+			addrs = append(addrs, addr)
+		}
+	}
+	if len(addrs) == 0 {
+		return
+	}
+
+	// Fill pool with new connections.
+	for _, v := range addrs {
+		delete(connMulti.absences, v)
+		if indexOf(v, connMulti.addrs) < 0 {
+			conn, _ := tarantool.Connect(v, connMulti.getConnOpts(v))
+			if conn != nil {
+				connMulti.setConnectionToPool(v, conn)
+			}
+		}
+	}
+	// Clear pool from obsolete connections. A retained address is placed
+	// after the address which precedes it in the current list to keep
+	// the order of addresses.
+	pos := 0
+	for _, v := range connMulti.addrs {
+		if i := indexOf(v, addrs); i >= 0 {
+			pos = i + 1
+			continue
+		}
+		connMulti.absences[v]++
+		if connMulti.absences[v] < connMulti.opts.DiscoveryEvictThreshold {
+			addrs = append(addrs, "")
+			copy(addrs[pos+1:], addrs[pos:])
+			addrs[pos] = v
+			pos++
+			continue
+		}
+		delete(connMulti.absences, v)
+		con, ok := connMulti.getConnectionFromPool(v)
+		if con != nil && ok {
+			con.Close()
+		}
+		connMulti.deleteConnectionFromPool(v)
+	}
+	connMulti.mutex.Lock()
+	connMulti.addrs = addrs
+	connMulti.mutex.Unlock()
+}
+
+// updateReadOnly refreshes the cached read-only statuses of the connected
+// instances concurrently. A status request is limited by
+// OptsMulti.CheckTimeout, so a hanging instance does not block the check.
+func (connMulti *ConnectionMulti) updateReadOnly() {
+	connMulti.mutex.RLock()
+	conns := make(map[string]*tarantool.Connection, len(connMulti.pool))
+	for addr, conn := range connMulti.pool {
+		if conn.ConnectedNow() {
+			conns[addr] = conn
+		}
+	}
+	connMulti.mutex.RUnlock()
+
+	readOnly := make(map[string]bool, len(conns))
+	var mutex sync.Mutex
+	workers := make(chan struct{}, reconnectWorkers)
+	var wg sync.WaitGroup
+	for addr, conn := range conns {
+		wg.Add(1)
+		workers <- struct{}{}
+		go func(addr string, conn *tarantool.Connection) {
+			defer wg.Done()
+			defer func() { <-workers }()
+
+			ctx, cancel := context.WithTimeout(context.Background(),
+				connMulti.opts.CheckTimeout)
+			defer cancel()
+
+			var ro []bool
+			req := tarantool.NewEvalRequest("return box.info.ro").Context(ctx)
+			if err := conn.Do(req).GetTyped(&ro); err == nil && len(ro) > 0 {
+				mutex.Lock()
+				readOnly[addr] = ro[0]
+				mutex.Unlock()
+			}
+		}(addr, conn)
+	}
+	wg.Wait()
+
+	connMulti.mutex.Lock()
+	connMulti.readOnly = readOnly
+	connMulti.mutex.Unlock()
+}
+
+// isHealthy returns true if the connection is established and its circuit
+// breaker does not fail requests fast.
+func isHealthy(conn *tarantool.Connection) bool {
+	return conn.ConnectedNow() && !conn.CircuitOpen()
+}
+
 func (connMulti *ConnectionMulti) getCurrentConnection() *tarantool.Connection {
 	connMulti.mutex.RLock()
 	defer connMulti.mutex.RUnlock()
@@ -242,7 +640,7 @@ func (connMulti *ConnectionMulti) getCurrentConnection() *tarantool.Connection {
 	for _, addr := range connMulti.addrs {
 		conn := connMulti.pool[addr]
 		if conn != nil {
-			if conn.ConnectedNow() {
+			if isHealthy(conn) {
 				return conn
 			}
 			connMulti.fallback = conn
@@ -251,11 +649,254 @@ func (connMulti *ConnectionMulti) getCurrentConnection() *tarantool.Connection {
 	return connMulti.fallback
 }
 
+// getBalancedConnection returns a connection for a stateless read request
+// according to the balancing policy.
+func (connMulti *ConnectionMulti) getBalancedConnection() *tarantool.Connection {
+	if connMulti.opts.Balancing == FirstHealthy {
+		return connMulti.getCurrentConnection()
+	}
+
+	connMulti.mutex.RLock()
+	connected := make([]*tarantool.Connection, 0, len(connMulti.addrs))
+	for _, addr := range connMulti.addrs {
+		conn := connMulti.pool[addr]
+		if conn != nil && isHealthy(conn) {
+			connected = append(connected, conn)
+		}
+	}
+	connMulti.mutex.RUnlock()
+
+	if len(connected) == 0 {
+		return connMulti.getCurrentConnection()
+	}
+
+	var idx int
+	switch connMulti.opts.Balancing {
+	case RoundRobin:
+		idx = int(atomic.AddUint32(&connMulti.balancerIdx, 1) % uint32(len(connected)))
+	case Random:
+		idx = rand.Intn(len(connected))
+	}
+	return connected[idx]
+}
+
+// getModeConnection returns a connection for a request according to the
+// mode and the cached read-only statuses.
+func (connMulti *ConnectionMulti) getModeConnection(mode Mode) (*tarantool.Connection, error) {
+	if mode == ANY {
+		return connMulti.getBalancedConnection(), nil
+	}
+
+	connMulti.mutex.RLock()
+	var rw, ro *tarantool.Connection
+	for _, addr := range connMulti.addrs {
+		conn := connMulti.pool[addr]
+		if conn == nil || !isHealthy(conn) {
+			continue
+		}
+		readOnly, known := connMulti.readOnly[addr]
+		if !known {
+			continue
+		}
+		if readOnly && ro == nil {
+			ro = conn
+		} else if !readOnly && rw == nil {
+			rw = conn
+		}
+	}
+	connMulti.mutex.RUnlock()
+
+	switch mode {
+	case RW:
+		if rw == nil {
+			return nil, ErrNoRwInstance
+		}
+		return rw, nil
+	case RO:
+		if ro == nil {
+			return nil, ErrNoRoInstance
+		}
+		return ro, nil
+	case PreferRW:
+		if rw != nil {
+			return rw, nil
+		} else if ro != nil {
+			return ro, nil
+		}
+		return nil, ErrNoConnection
+	case PreferRO:
+		if ro != nil {
+			return ro, nil
+		} else if rw != nil {
+			return rw, nil
+		}
+		return nil, ErrNoConnection
+	}
+	return nil, fmt.Errorf("unexpected mode %d", mode)
+}
+
+// getHedgeConnection returns a connected connection other than the passed
+// one or nil if there is no such connection.
+func (connMulti *ConnectionMulti) getHedgeConnection(current *tarantool.Connection) *tarantool.Connection {
+	connMulti.mutex.RLock()
+	defer connMulti.mutex.RUnlock()
+
+	for _, addr := range connMulti.addrs {
+		conn := connMulti.pool[addr]
+		if conn != nil && conn != current && isHealthy(conn) {
+			return conn
+		}
+	}
+	return nil
+}
+
+// hedge sends a read request created by the doRequest to the current
+// connection and, if it does not respond within HedgeDelay, to one more
+// connection. The first successful response is set to the returned future.
+func (connMulti *ConnectionMulti) hedge(doRequest func(conn *tarantool.Connection,
+	ctx context.Context) *tarantool.Future) *tarantool.Future {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancels := []context.CancelFunc{cancel}
+	// send sends the request with a context which honours Opts.Timeout of
+	// the connection: requests with a context are not timed out by
+	// the connection itself.
+	send := func(conn *tarantool.Connection) hedgeFuture {
+		reqCtx := ctx
+		if timeout := conn.ConfiguredTimeout(); timeout > 0 {
+			var reqCancel context.CancelFunc
+			reqCtx, reqCancel = context.WithTimeout(ctx, timeout)
+			cancels = append(cancels, reqCancel)
+		}
+		return hedgeFuture{doRequest(conn, reqCtx), reqCtx}
+	}
+
+	current := connMulti.getBalancedConnection()
+	first := send(current)
+
+	fut := tarantool.NewFuture()
+	go func() {
+		defer func() {
+			for _, cancel := range cancels {
+				cancel()
+			}
+		}()
+
+		if connMulti.opts.HedgeDelay > 0 {
+			timer := time.NewTimer(connMulti.opts.HedgeDelay)
+			select {
+			case <-first.WaitChan():
+				timer.Stop()
+				setHedgeResult(fut, first)
+				return
+			case <-timer.C:
+			}
+		}
+
+		other := connMulti.getHedgeConnection(current)
+		if other == nil {
+			setHedgeResult(fut, first)
+			return
+		}
+		atomic.AddUint64(&connMulti.hedged, 1)
+		second := send(other)
+
+		var winner, loser hedgeFuture
+		select {
+		case <-first.WaitChan():
+			winner, loser = first, second
+		case <-second.WaitChan():
+			winner, loser = second, first
+		}
+		if winner.Err() != nil {
+			// The slower request may succeed.
+			<-loser.WaitChan()
+			winner = loser
+		}
+		if winner == second {
+			atomic.AddUint64(&connMulti.hedgedWins, 1)
+		}
+		// The slower request is cancelled by the deferred cancels.
+		setHedgeResult(fut, winner)
+	}()
+	return fut
+}
+
+// hedgeFuture is a future of a hedged request with the request context.
+type hedgeFuture struct {
+	*tarantool.Future
+	ctx context.Context
+}
+
+func setHedgeResult(fut *tarantool.Future, from hedgeFuture) {
+	resp, err := from.Get()
+	if err != nil && from.ctx.Err() == context.DeadlineExceeded {
+		err = tarantool.ClientError{
+			Code: tarantool.ErrTimeouted,
+			Msg:  "client timeout for a hedged request",
+		}
+	}
+	if err != nil {
+		fut.SetError(err)
+	} else {
+		fut.SetResponse(resp)
+	}
+}
+
+// HedgeStats returns statistics of hedged requests.
+func (connMulti *ConnectionMulti) HedgeStats() HedgeStats {
+	return HedgeStats{
+		Hedged:     atomic.LoadUint64(&connMulti.hedged),
+		HedgedWins: atomic.LoadUint64(&connMulti.hedgedWins),
+	}
+}
+
 // ConnectedNow reports if connection is established at the moment.
 func (connMulti *ConnectionMulti) ConnectedNow() bool {
 	return connMulti.getState() == connConnected && connMulti.getCurrentConnection().ConnectedNow()
 }
 
+// Degraded reports if there is no connected instance at the moment and
+// requests are sent to a disconnected fallback connection. It returns false
+// for a closed ConnectionMulti.
+func (connMulti *ConnectionMulti) Degraded() bool {
+	return connMulti.getState() == connConnected &&
+		!connMulti.getCurrentConnection().ConnectedNow()
+}
+
+// EachConnection calls the function for each connected instance of the pool
+// in the order of addresses. The function is called for all connections even
+// if it fails for some of them, the errors are returned as EachError.
+//
+// The function is called without the pool lock held, so it could send
+// requests with the connection or the ConnectionMulti.
+func (connMulti *ConnectionMulti) EachConnection(
+	fn func(addr string, conn *tarantool.Connection) error) error {
+	type poolConn struct {
+		addr string
+		conn *tarantool.Connection
+	}
+
+	connMulti.mutex.RLock()
+	conns := make([]poolConn, 0, len(connMulti.addrs))
+	for _, addr := range connMulti.addrs {
+		if conn := connMulti.pool[addr]; conn != nil && conn.ConnectedNow() {
+			conns = append(conns, poolConn{addr, conn})
+		}
+	}
+	connMulti.mutex.RUnlock()
+
+	errs := make(map[string]error)
+	for _, c := range conns {
+		if err := fn(c.addr, c.conn); err != nil {
+			errs[c.addr] = err
+		}
+	}
+	if len(errs) > 0 {
+		return EachError{Errors: errs}
+	}
+	return nil
+}
+
 // Close closes Connection.
 // After this method called, there is no way to reopen this Connection.
 func (connMulti *ConnectionMulti) Close() (err error) {
@@ -281,7 +922,7 @@ func (connMulti *ConnectionMulti) Close() (err error) {
 
 // Ping sends empty request to Tarantool to check connection.
 func (connMulti *ConnectionMulti) Ping() (resp *tarantool.Response, err error) {
-	return connMulti.getCurrentConnection().Ping()
+	return connMulti.getBalancedConnection().Ping()
 }
 
 // ConfiguredTimeout returns a timeout from connection config.
@@ -291,36 +932,54 @@ func (connMulti *ConnectionMulti) ConfiguredTimeout() time.Duration {
 
 // Select performs select to box space.
 func (connMulti *ConnectionMulti) Select(space, index interface{}, offset, limit, iterator uint32, key interface{}) (resp *tarantool.Response, err error) {
-	return connMulti.getCurrentConnection().Select(space, index, offset, limit, iterator, key)
+	if connMulti.opts.HedgeReads {
+		return connMulti.SelectAsync(space, index, offset, limit, iterator, key).Get()
+	}
+	return connMulti.getBalancedConnection().Select(space, index, offset, limit, iterator, key)
 }
 
 // Insert performs insertion to box space.
 // Tarantool will reject Insert when tuple with same primary key exists.
 func (connMulti *ConnectionMulti) Insert(space interface{}, tuple interface{}) (resp *tarantool.Response, err error) {
+	if connMulti.opts.ReadOnly {
+		return nil, ErrReadOnly
+	}
 	return connMulti.getCurrentConnection().Insert(space, tuple)
 }
 
 // Replace performs "insert or replace" action to box space.
 // If tuple with same primary key exists, it will be replaced.
 func (connMulti *ConnectionMulti) Replace(space interface{}, tuple interface{}) (resp *tarantool.Response, err error) {
+	if connMulti.opts.ReadOnly {
+		return nil, ErrReadOnly
+	}
 	return connMulti.getCurrentConnection().Replace(space, tuple)
 }
 
 // Delete performs deletion of a tuple by key.
 // Result will contain array with deleted tuple.
 func (connMulti *ConnectionMulti) Delete(space, index interface{}, key interface{}) (resp *tarantool.Response, err error) {
+	if connMulti.opts.ReadOnly {
+		return nil, ErrReadOnly
+	}
 	return connMulti.getCurrentConnection().Delete(space, index, key)
 }
 
 // Update performs update of a tuple by key.
 // Result will contain array with updated tuple.
 func (connMulti *ConnectionMulti) Update(space, index interface{}, key, ops interface{}) (resp *tarantool.Response, err error) {
+	if connMulti.opts.ReadOnly {
+		return nil, ErrReadOnly
+	}
 	return connMulti.getCurrentConnection().Update(space, index, key, ops)
 }
 
 // Upsert performs "update or insert" action of a tuple by key.
 // Result will not contain any tuple.
 func (connMulti *ConnectionMulti) Upsert(space interface{}, tuple, ops interface{}) (resp *tarantool.Response, err error) {
+	if connMulti.opts.ReadOnly {
+		return nil, ErrReadOnly
+	}
 	return connMulti.getCurrentConnection().Upsert(space, tuple, ops)
 }
 
@@ -329,7 +988,7 @@ func (connMulti *ConnectionMulti) Upsert(space interface{}, tuple, ops interface
 // was build with go_tarantool_call_17 tag.
 // Otherwise, uses request code for Tarantool 1.6.
 func (connMulti *ConnectionMulti) Call(functionName string, args interface{}) (resp *tarantool.Response, err error) {
-	return connMulti.getCurrentConnection().Call(functionName, args)
+	return connMulti.getBalancedConnection().Call(functionName, args)
 }
 
 // Call16 calls registered Tarantool function.
@@ -337,19 +996,19 @@ func (connMulti *ConnectionMulti) Call(functionName string, args interface{}) (r
 // arrays.
 // Deprecated since Tarantool 1.7.2.
 func (connMulti *ConnectionMulti) Call16(functionName string, args interface{}) (resp *tarantool.Response, err error) {
-	return connMulti.getCurrentConnection().Call16(functionName, args)
+	return connMulti.getBalancedConnection().Call16(functionName, args)
 }
 
 // Call17 calls registered Tarantool function.
 // It uses request code for Tarantool >= 1.7, so result is not converted
 // (though, keep in mind, result is always array).
 func (connMulti *ConnectionMulti) Call17(functionName string, args interface{}) (resp *tarantool.Response, err error) {
-	return connMulti.getCurrentConnection().Call17(functionName, args)
+	return connMulti.getBalancedConnection().Call17(functionName, args)
 }
 
 // Eval passes Lua expression for evaluation.
 func (connMulti *ConnectionMulti) Eval(expr string, args interface{}) (resp *tarantool.Response, err error) {
-	return connMulti.getCurrentConnection().Eval(expr, args)
+	return connMulti.getBalancedConnection().Eval(expr, args)
 }
 
 // Execute passes sql expression to Tarantool for execution.
@@ -362,35 +1021,50 @@ func (connMulti *ConnectionMulti) Execute(expr string, args interface{}) (resp *
 // GetTyped performs select (with limit = 1 and offset = 0) to box space and
 // fills typed result.
 func (connMulti *ConnectionMulti) GetTyped(space, index interface{}, key interface{}, result interface{}) (err error) {
-	return connMulti.getCurrentConnection().GetTyped(space, index, key, result)
+	return connMulti.getBalancedConnection().GetTyped(space, index, key, result)
 }
 
 // SelectTyped performs select to box space and fills typed result.
 func (connMulti *ConnectionMulti) SelectTyped(space, index interface{}, offset, limit, iterator uint32, key interface{}, result interface{}) (err error) {
-	return connMulti.getCurrentConnection().SelectTyped(space, index, offset, limit, iterator, key, result)
+	if connMulti.opts.HedgeReads {
+		return connMulti.SelectAsync(space, index, offset, limit, iterator, key).GetTyped(result)
+	}
+	return connMulti.getBalancedConnection().SelectTyped(space, index, offset, limit, iterator, key, result)
 }
 
 // InsertTyped performs insertion to box space.
 // Tarantool will reject Insert when tuple with same primary key exists.
 func (connMulti *ConnectionMulti) InsertTyped(space interface{}, tuple interface{}, result interface{}) (err error) {
+	if connMulti.opts.ReadOnly {
+		return ErrReadOnly
+	}
 	return connMulti.getCurrentConnection().InsertTyped(space, tuple, result)
 }
 
 // ReplaceTyped performs "insert or replace" action to box space.
 // If tuple with same primary key exists, it will be replaced.
 func (connMulti *ConnectionMulti) ReplaceTyped(space interface{}, tuple interface{}, result interface{}) (err error) {
+	if connMulti.opts.ReadOnly {
+		return ErrReadOnly
+	}
 	return connMulti.getCurrentConnection().ReplaceTyped(space, tuple, result)
 }
 
 // DeleteTyped performs deletion of a tuple by key and fills result with
 // deleted tuple.
 func (connMulti *ConnectionMulti) DeleteTyped(space, index interface{}, key interface{}, result interface{}) (err error) {
+	if connMulti.opts.ReadOnly {
+		return ErrReadOnly
+	}
 	return connMulti.getCurrentConnection().DeleteTyped(space, index, key, result)
 }
 
 // UpdateTyped performs update of a tuple by key and fills result with updated
 // tuple.
 func (connMulti *ConnectionMulti) UpdateTyped(space, index interface{}, key, ops interface{}, result interface{}) (err error) {
+	if connMulti.opts.ReadOnly {
+		return ErrReadOnly
+	}
 	return connMulti.getCurrentConnection().UpdateTyped(space, index, key, ops, result)
 }
 
@@ -399,7 +1073,7 @@ func (connMulti *ConnectionMulti) UpdateTyped(space, index interface{}, key, ops
 // was build with go_tarantool_call_17 tag.
 // Otherwise, uses request code for Tarantool 1.6.
 func (connMulti *ConnectionMulti) CallTyped(functionName string, args interface{}, result interface{}) (err error) {
-	return connMulti.getCurrentConnection().CallTyped(functionName, args, result)
+	return connMulti.getBalancedConnection().CallTyped(functionName, args, result)
 }
 
 // Call16Typed calls registered function.
@@ -407,19 +1081,19 @@ func (connMulti *ConnectionMulti) CallTyped(functionName string, args interface{
 // arrays.
 // Deprecated since Tarantool 1.7.2.
 func (connMulti *ConnectionMulti) Call16Typed(functionName string, args interface{}, result interface{}) (err error) {
-	return connMulti.getCurrentConnection().Call16Typed(functionName, args, result)
+	return connMulti.getBalancedConnection().Call16Typed(functionName, args, result)
 }
 
 // Call17Typed calls registered function.
 // It uses request code for Tarantool >= 1.7, so result is not converted (though,
 // keep in mind, result is always array)
 func (connMulti *ConnectionMulti) Call17Typed(functionName string, args interface{}, result interface{}) (err error) {
-	return connMulti.getCurrentConnection().Call17Typed(functionName, args, result)
+	return connMulti.getBalancedConnection().Call17Typed(functionName, args, result)
 }
 
 // EvalTyped passes Lua expression for evaluation.
 func (connMulti *ConnectionMulti) EvalTyped(expr string, args interface{}, result interface{}) (err error) {
-	return connMulti.getCurrentConnection().EvalTyped(expr, args, result)
+	return connMulti.getBalancedConnection().EvalTyped(expr, args, result)
 }
 
 // ExecuteTyped passes sql expression to Tarantool for execution.
@@ -429,36 +1103,63 @@ func (connMulti *ConnectionMulti) ExecuteTyped(expr string, args interface{}, re
 
 // SelectAsync sends select request to Tarantool and returns Future.
 func (connMulti *ConnectionMulti) SelectAsync(space, index interface{}, offset, limit, iterator uint32, key interface{}) *tarantool.Future {
-	return connMulti.getCurrentConnection().SelectAsync(space, index, offset, limit, iterator, key)
+	if connMulti.opts.HedgeReads {
+		return connMulti.hedge(func(conn *tarantool.Connection, ctx context.Context) *tarantool.Future {
+			req := tarantool.NewSelectRequest(space).
+				Index(index).
+				Offset(offset).
+				Limit(limit).
+				Iterator(iterator).
+				Key(key).
+				Context(ctx)
+			return conn.Do(req)
+		})
+	}
+	return connMulti.getBalancedConnection().SelectAsync(space, index, offset, limit, iterator, key)
 }
 
 // InsertAsync sends insert action to Tarantool and returns Future.
 // Tarantool will reject Insert when tuple with same primary key exists.
 func (connMulti *ConnectionMulti) InsertAsync(space interface{}, tuple interface{}) *tarantool.Future {
+	if connMulti.opts.ReadOnly {
+		return newErrorFuture(ErrReadOnly)
+	}
 	return connMulti.getCurrentConnection().InsertAsync(space, tuple)
 }
 
 // ReplaceAsync sends "insert or replace" action to Tarantool and returns Future.
 // If tuple with same primary key exists, it will be replaced.
 func (connMulti *ConnectionMulti) ReplaceAsync(space interface{}, tuple interface{}) *tarantool.Future {
+	if connMulti.opts.ReadOnly {
+		return newErrorFuture(ErrReadOnly)
+	}
 	return connMulti.getCurrentConnection().ReplaceAsync(space, tuple)
 }
 
 // DeleteAsync sends deletion action to Tarantool and returns Future.
 // Future's result will contain array with deleted tuple.
 func (connMulti *ConnectionMulti) DeleteAsync(space, index interface{}, key interface{}) *tarantool.Future {
+	if connMulti.opts.ReadOnly {
+		return newErrorFuture(ErrReadOnly)
+	}
 	return connMulti.getCurrentConnection().DeleteAsync(space, index, key)
 }
 
 // Update sends deletion of a tuple by key and returns Future.
 // Future's result will contain array with updated tuple.
 func (connMulti *ConnectionMulti) UpdateAsync(space, index interface{}, key, ops interface{}) *tarantool.Future {
+	if connMulti.opts.ReadOnly {
+		return newErrorFuture(ErrReadOnly)
+	}
 	return connMulti.getCurrentConnection().UpdateAsync(space, index, key, ops)
 }
 
 // UpsertAsync sends "update or insert" action to Tarantool and returns Future.
 // Future's sesult will not contain any tuple.
 func (connMulti *ConnectionMulti) UpsertAsync(space interface{}, tuple interface{}, ops interface{}) *tarantool.Future {
+	if connMulti.opts.ReadOnly {
+		return newErrorFuture(ErrReadOnly)
+	}
 	return connMulti.getCurrentConnection().UpsertAsync(space, tuple, ops)
 }
 
@@ -467,7 +1168,7 @@ func (connMulti *ConnectionMulti) UpsertAsync(space interface{}, tuple interface
 // was build with go_tarantool_call_17 tag.
 // Otherwise, uses request code for Tarantool 1.6.
 func (connMulti *ConnectionMulti) CallAsync(functionName string, args interface{}) *tarantool.Future {
-	return connMulti.getCurrentConnection().CallAsync(functionName, args)
+	return connMulti.getBalancedConnection().CallAsync(functionName, args)
 }
 
 // Call16Async sends a call to registered Tarantool function and returns Future.
@@ -475,19 +1176,19 @@ func (connMulti *ConnectionMulti) CallAsync(functionName string, args interface{
 // of arrays.
 // Deprecated since Tarantool 1.7.2.
 func (connMulti *ConnectionMulti) Call16Async(functionName string, args interface{}) *tarantool.Future {
-	return connMulti.getCurrentConnection().Call16Async(functionName, args)
+	return connMulti.getBalancedConnection().Call16Async(functionName, args)
 }
 
 // Call17Async sends a call to registered Tarantool function and returns Future.
 // It uses request code for Tarantool >= 1.7, so future's result will not be converted
 // (though, keep in mind, result is always array).
 func (connMulti *ConnectionMulti) Call17Async(functionName string, args interface{}) *tarantool.Future {
-	return connMulti.getCurrentConnection().Call17Async(functionName, args)
+	return connMulti.getBalancedConnection().Call17Async(functionName, args)
 }
 
 // EvalAsync passes Lua expression for evaluation.
 func (connMulti *ConnectionMulti) EvalAsync(expr string, args interface{}) *tarantool.Future {
-	return connMulti.getCurrentConnection().EvalAsync(expr, args)
+	return connMulti.getBalancedConnection().EvalAsync(expr, args)
 }
 
 // ExecuteAsync passes sql expression to Tarantool for execution.
@@ -521,7 +1222,8 @@ func (connMulti *ConnectionMulti) NewWatcher(key string,
 
 // Do sends the request and returns a future.
 func (connMulti *ConnectionMulti) Do(req tarantool.Request) *tarantool.Future {
-	if connectedReq, ok := req.(tarantool.ConnectedRequest); ok {
+	if connectedReq, ok := req.(tarantool.ConnectedRequest); ok &&
+		connectedReq.Conn() != nil {
 		_, belongs := connMulti.getConnectionFromPool(connectedReq.Conn().Addr())
 		if !belongs {
 			fut := tarantool.NewFuture()
@@ -530,5 +1232,62 @@ func (connMulti *ConnectionMulti) Do(req tarantool.Request) *tarantool.Future {
 		}
 		return connectedReq.Conn().Do(req)
 	}
+	if connMulti.opts.ReadOnly && isWriteRequest(req) {
+		return newErrorFuture(ErrReadOnly)
+	}
+	if isBalancedRequest(req) {
+		return connMulti.getBalancedConnection().Do(req)
+	}
 	return connMulti.getCurrentConnection().Do(req)
 }
+
+// DoMode sends the request to an instance selected by the mode and returns
+// a future. The instances are selected by read-only statuses which are
+// refreshed each CheckTimeout. It allows to route requests which could not
+// be classified by the connector, like a call of a writing function.
+// ANY mode and connected requests are handled as Do does.
+func (connMulti *ConnectionMulti) DoMode(req tarantool.Request, mode Mode) *tarantool.Future {
+	if connectedReq, ok := req.(tarantool.ConnectedRequest); mode == ANY ||
+		ok && connectedReq.Conn() != nil {
+		return connMulti.Do(req)
+	}
+	if connMulti.opts.ReadOnly && isWriteRequest(req) {
+		return newErrorFuture(ErrReadOnly)
+	}
+
+	conn, err := connMulti.getModeConnection(mode)
+	if err != nil {
+		return newErrorFuture(err)
+	}
+	return conn.Do(req)
+}
+
+// isBalancedRequest returns true for requests that are balanced between
+// connected instances according to OptsMulti.Balancing.
+func isBalancedRequest(req tarantool.Request) bool {
+	switch req.Code() {
+	case tarantool.PingRequestCode, tarantool.SelectRequestCode,
+		tarantool.Call16RequestCode, tarantool.Call17RequestCode,
+		tarantool.EvalRequestCode:
+		return true
+	}
+	return false
+}
+
+// isWriteRequest returns true for requests that are forbidden in read-only
+// mode.
+func isWriteRequest(req tarantool.Request) bool {
+	switch req.Code() {
+	case tarantool.InsertRequestCode, tarantool.ReplaceRequestCode,
+		tarantool.DeleteRequestCode, tarantool.UpdateRequestCode,
+		tarantool.UpsertRequestCode:
+		return true
+	}
+	return false
+}
+
+func newErrorFuture(err error) *tarantool.Future {
+	fut := tarantool.NewFuture()
+	fut.SetError(err)
+	return fut
+}
@@ -5,6 +5,7 @@ import (
 	"log"
 	"os"
 	"reflect"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -69,8 +70,120 @@ func TestConnError_Connection(t *testing.T) {
 	}
 }
 
+func TestConnError_InvalidAddr(t *testing.T) {
+	invalid := []string{"err", "127.0.0.1", "127.0.0.1:port", "127.0.0.1:0",
+		"unix:", ""}
+	for _, addr := range invalid {
+		multiConn, err := Connect([]string{server1, addr}, connOpts)
+		require.NotNilf(t, err, "err is nil for address %q", addr)
+		require.Contains(t, err.Error(), "invalid address")
+		require.Nil(t, multiConn)
+	}
+}
+
+func TestConnDuplicateAddrs(t *testing.T) {
+	multiConn, err := Connect([]string{server1, server2, server1, server2}, connOpts)
+	require.Nilf(t, err, "failed to connect")
+	require.NotNilf(t, multiConn, "conn is nil after Connect")
+	defer multiConn.Close()
+
+	require.Equal(t, []string{server1, server2}, multiConn.addrs)
+	require.Equal(t, 2, len(multiConn.pool))
+}
+
+func TestDiscoveryEvictThreshold(t *testing.T) {
+	opts := connOptsMulti
+	opts.NodesGetFunctionName = ""
+	opts.DiscoveryEvictThreshold = 2
+
+	multiConn, err := ConnectWithOpts([]string{server1, server2}, connOpts, opts)
+	require.Nilf(t, err, "failed to connect")
+	require.NotNilf(t, multiConn, "conn is nil after Connect")
+	defer multiConn.Close()
+
+	multiConn.updateAddrs([]string{server1})
+	require.Equal(t, []string{server1, server2}, multiConn.addrs)
+	multiConn.updateAddrs([]string{server1, server2})
+	multiConn.updateAddrs([]string{server1})
+	require.Equal(t, []string{server1, server2}, multiConn.addrs)
+	_, ok := multiConn.getConnectionFromPool(server2)
+	require.True(t, ok)
+
+	multiConn.updateAddrs([]string{server1})
+	require.Equal(t, []string{server1}, multiConn.addrs)
+	_, ok = multiConn.getConnectionFromPool(server2)
+	require.False(t, ok)
+
+	multiConn.updateAddrs([]string{server2, server1})
+	multiConn.updateAddrs([]string{server1})
+	require.Equal(t, []string{server2, server1}, multiConn.addrs)
+}
+
+type slowDialer struct {
+	delay time.Duration
+}
+
+func (d slowDialer) Dial(address string, opts tarantool.DialOpts) (tarantool.Conn, error) {
+	time.Sleep(d.delay)
+	return tarantool.TtDialer{}.Dial(address, opts)
+}
+
+func TestConnectTimeout(t *testing.T) {
+	const delay = 2 * time.Second
+	opts := connOptsMulti
+	opts.ConnectTimeout = 200 * time.Millisecond
+	opts.PerNodeOpts = map[string]tarantool.Opts{
+		server2: {Dialer: slowDialer{delay}},
+	}
+
+	start := time.Now()
+	multiConn, err := ConnectWithOpts([]string{server1, server2}, connOpts, opts)
+	require.Nilf(t, err, "failed to connect")
+	require.NotNilf(t, multiConn, "conn is nil after Connect")
+	defer multiConn.Close()
+
+	require.Less(t, time.Since(start), delay)
+	require.True(t, multiConn.ConnectedNow())
+	_, ok := multiConn.getConnectionFromPool(server2)
+	require.False(t, ok)
+
+	time.Sleep(delay)
+	_, ok = multiConn.getConnectionFromPool(server2)
+	require.True(t, ok)
+}
+
+func TestReconnectClosed_parallel(t *testing.T) {
+	const delay = 500 * time.Millisecond
+	opts := connOptsMulti
+	opts.PerNodeOpts = map[string]tarantool.Opts{
+		server1: {Dialer: slowDialer{delay}},
+		server2: {Dialer: slowDialer{delay}},
+	}
+
+	multiConn, err := ConnectWithOpts([]string{server1, server2}, connOpts, opts)
+	require.Nilf(t, err, "failed to connect")
+	require.NotNilf(t, multiConn, "conn is nil after Connect")
+	defer multiConn.Close()
+
+	for _, addr := range []string{server1, server2} {
+		conn, ok := multiConn.getConnectionFromPool(addr)
+		require.True(t, ok)
+		conn.Close()
+	}
+
+	start := time.Now()
+	multiConn.reconnectClosed()
+	require.Less(t, time.Since(start), 2*delay)
+
+	for _, addr := range []string{server1, server2} {
+		conn, ok := multiConn.getConnectionFromPool(addr)
+		require.True(t, ok)
+		require.True(t, conn.ConnectedNow())
+	}
+}
+
 func TestConnSuccessfully(t *testing.T) {
-	multiConn, err := Connect([]string{"err", server1}, connOpts)
+	multiConn, err := Connect([]string{"127.0.0.1:1", server1}, connOpts)
 	if err != nil {
 		t.Errorf("Failed to connect: %s", err.Error())
 		return
@@ -91,6 +204,165 @@ func TestConnSuccessfully(t *testing.T) {
 	}
 }
 
+func TestConnPerNodeOpts(t *testing.T) {
+	opts := connOptsMulti
+	opts.PerNodeOpts = map[string]tarantool.Opts{
+		server1: {
+			User: "not_exist_user",
+			Pass: "not_exist_pass",
+		},
+	}
+
+	multiConn, err := ConnectWithOpts([]string{server1, server2}, connOpts, opts)
+	require.Nilf(t, err, "failed to connect")
+	require.NotNilf(t, multiConn, "conn is nil after Connect")
+	defer multiConn.Close()
+
+	require.True(t, multiConn.ConnectedNow())
+	require.Equal(t, server2, multiConn.getCurrentConnection().Addr())
+}
+
+func TestConnPerNodeOpts_credentialsProvider(t *testing.T) {
+	var calls int32
+	opts := connOptsMulti
+	opts.PerNodeOpts = map[string]tarantool.Opts{
+		server1: {
+			CredentialsProvider: func() (string, string, error) {
+				return "", "", fmt.Errorf("no credentials")
+			},
+		},
+		server2: {
+			CredentialsProvider: func() (string, string, error) {
+				atomic.AddInt32(&calls, 1)
+				return connOpts.User, connOpts.Pass, nil
+			},
+		},
+	}
+
+	multiConn, err := ConnectWithOpts([]string{server1, server2}, connOpts, opts)
+	require.Nilf(t, err, "failed to connect")
+	require.NotNilf(t, multiConn, "conn is nil after Connect")
+	defer multiConn.Close()
+
+	require.True(t, multiConn.ConnectedNow())
+	require.Equal(t, server2, multiConn.getCurrentConnection().Addr())
+	require.Greater(t, atomic.LoadInt32(&calls), int32(0))
+}
+
+func TestMergeOpts(t *testing.T) {
+	notify := make(chan tarantool.ConnEvent)
+	base := tarantool.Opts{
+		Timeout:   time.Second,
+		Reconnect: time.Second,
+		User:      "user",
+		Pass:      "pass",
+		Notify:    notify,
+	}
+	node := tarantool.Opts{
+		Timeout: 5 * time.Second,
+		User:    "node_user",
+		Pass:    "node_pass",
+	}
+
+	merged := mergeOpts(base, node)
+	require.Equal(t, 5*time.Second, merged.Timeout)
+	require.Equal(t, time.Second, merged.Reconnect)
+	require.Equal(t, "node_user", merged.User)
+	require.Equal(t, "node_pass", merged.Pass)
+	require.Equal(t, (chan<- tarantool.ConnEvent)(notify), merged.Notify)
+}
+
+type testLogger struct{}
+
+func (testLogger) Report(event tarantool.ConnLogKind, conn *tarantool.Connection,
+	v ...interface{}) {
+}
+
+// nonZeroValue returns a non-zero value of the type. Values of interfaces
+// are taken from the map by the type.
+func nonZeroValue(t *testing.T, typ reflect.Type,
+	ifaces map[reflect.Type]interface{}) reflect.Value {
+	val := reflect.New(typ).Elem()
+	switch typ.Kind() {
+	case reflect.Bool:
+		val.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		val.SetInt(1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64:
+		val.SetUint(1)
+	case reflect.Float32, reflect.Float64:
+		val.SetFloat(1)
+	case reflect.String:
+		val.SetString("value")
+	case reflect.Func:
+		val.Set(reflect.MakeFunc(typ, func(args []reflect.Value) []reflect.Value {
+			results := make([]reflect.Value, typ.NumOut())
+			for i := range results {
+				results[i] = reflect.Zero(typ.Out(i))
+			}
+			return results
+		}))
+	case reflect.Chan:
+		ch := reflect.MakeChan(reflect.ChanOf(reflect.BothDir, typ.Elem()), 0)
+		val.Set(ch.Convert(typ))
+	case reflect.Slice:
+		val.Set(reflect.MakeSlice(typ, 1, 1))
+		val.Index(0).Set(nonZeroValue(t, typ.Elem(), ifaces))
+	case reflect.Map:
+		val.Set(reflect.MakeMap(typ))
+	case reflect.Struct:
+		val.Field(0).Set(nonZeroValue(t, typ.Field(0).Type, ifaces))
+	case reflect.Interface:
+		iface, ok := ifaces[typ]
+		if !ok {
+			t.Fatalf("no value for the interface type %s", typ)
+		}
+		val.Set(reflect.ValueOf(iface))
+	default:
+		t.Fatalf("unsupported kind %s of the type %s", typ.Kind(), typ)
+	}
+	return val
+}
+
+func TestMergeOpts_allFields(t *testing.T) {
+	ifaces := map[reflect.Type]interface{}{
+		reflect.TypeOf((*tarantool.Dialer)(nil)).Elem(): tarantool.TtDialer{},
+		reflect.TypeOf((*tarantool.Logger)(nil)).Elem(): testLogger{},
+		reflect.TypeOf((*interface{})(nil)).Elem():      1,
+	}
+
+	typ := reflect.TypeOf(tarantool.Opts{})
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.Name == "Notify" {
+			continue
+		}
+		t.Run(field.Name, func(t *testing.T) {
+			var node tarantool.Opts
+			nodeVal := reflect.ValueOf(&node).Elem()
+			nodeVal.Field(i).Set(nonZeroValue(t, field.Type, ifaces))
+			// Paired fields are taken together.
+			switch field.Name {
+			case "Pass":
+				node.User = "user"
+			case "RLimitAction":
+				node.RateLimit = 1
+			case "Ssl":
+				node.Transport = "ssl"
+			}
+
+			merged := reflect.ValueOf(mergeOpts(tarantool.Opts{}, node))
+			expected, actual := nodeVal.Field(i), merged.Field(i)
+			if field.Type.Kind() == reflect.Func {
+				require.Equal(t, expected.Pointer(), actual.Pointer())
+			} else {
+				require.Equal(t, expected.Interface(), actual.Interface())
+			}
+		})
+	}
+}
+
 func TestReconnect(t *testing.T) {
 	multiConn, _ := Connect([]string{server1, server2}, connOpts)
 	if multiConn == nil {
@@ -130,6 +402,10 @@ func TestDisconnectAll(t *testing.T) {
 		return
 	}
 
+	if multiConn.Degraded() {
+		t.Errorf("incorrect degraded status after connect")
+	}
+
 	for _, inst := range instances {
 		test_helpers.StopTarantoolWithCleanup(inst)
 	}
@@ -141,6 +417,9 @@ func TestDisconnectAll(t *testing.T) {
 	if multiConn.ConnectedNow() {
 		t.Errorf("incorrect status after desconnect all")
 	}
+	if !multiConn.Degraded() {
+		t.Errorf("incorrect degraded status after desconnect all")
+	}
 
 	for _, inst := range instances {
 		err := test_helpers.RestartTarantool(&inst)
@@ -156,6 +435,9 @@ func TestDisconnectAll(t *testing.T) {
 	if !multiConn.ConnectedNow() {
 		t.Errorf("incorrect multiConn status after reconnecting")
 	}
+	if multiConn.Degraded() {
+		t.Errorf("incorrect degraded status after reconnecting")
+	}
 }
 
 func TestClose(t *testing.T) {
@@ -250,6 +532,282 @@ func TestCall17(t *testing.T) {
 	}
 }
 
+func TestHedgeReads(t *testing.T) {
+	opts := connOptsMulti
+	opts.HedgeReads = true
+
+	multiConn, err := ConnectWithOpts([]string{server1, server2}, connOpts, opts)
+	require.Nilf(t, err, "failed to connect")
+	require.NotNilf(t, multiConn, "conn is nil after Connect")
+	defer multiConn.Close()
+
+	resp, err := multiConn.Select(spaceNo, indexNo, 0, 1, tarantool.IterAll, []interface{}{})
+	require.Nilf(t, err, "failed to Select")
+	require.NotNilf(t, resp, "response is nil after Select")
+
+	stats := multiConn.HedgeStats()
+	require.Equal(t, uint64(1), stats.Hedged)
+	require.LessOrEqual(t, stats.HedgedWins, stats.Hedged)
+}
+
+func TestHedgeReads_Delay(t *testing.T) {
+	opts := connOptsMulti
+	opts.HedgeReads = true
+	opts.HedgeDelay = 10 * time.Second
+
+	multiConn, err := ConnectWithOpts([]string{server1, server2}, connOpts, opts)
+	require.Nilf(t, err, "failed to connect")
+	require.NotNilf(t, multiConn, "conn is nil after Connect")
+	defer multiConn.Close()
+
+	var tuples [][]interface{}
+	err = multiConn.SelectTyped(spaceNo, indexNo, 0, 1, tarantool.IterAll, []interface{}{}, &tuples)
+	require.Nilf(t, err, "failed to SelectTyped")
+
+	require.Equal(t, HedgeStats{}, multiConn.HedgeStats())
+}
+
+type blackholeConn struct {
+	tarantool.Conn
+	drop *int32
+}
+
+func (c blackholeConn) Write(b []byte) (int, error) {
+	if atomic.LoadInt32(c.drop) != 0 {
+		return len(b), nil
+	}
+	return c.Conn.Write(b)
+}
+
+type blackholeDialer struct {
+	drop *int32
+}
+
+func (d blackholeDialer) Dial(address string, opts tarantool.DialOpts) (tarantool.Conn, error) {
+	conn, err := tarantool.TtDialer{}.Dial(address, opts)
+	if err != nil {
+		return nil, err
+	}
+	return blackholeConn{conn, d.drop}, nil
+}
+
+func TestHedgeReads_Timeout(t *testing.T) {
+	var drop int32
+	opts := connOptsMulti
+	opts.HedgeReads = true
+	opts.PerNodeOpts = map[string]tarantool.Opts{
+		server1: {Dialer: blackholeDialer{&drop}},
+		server2: {Dialer: blackholeDialer{&drop}},
+	}
+
+	multiConn, err := ConnectWithOpts([]string{server1, server2}, connOpts, opts)
+	require.Nilf(t, err, "failed to connect")
+	require.NotNilf(t, multiConn, "conn is nil after Connect")
+	defer multiConn.Close()
+
+	atomic.StoreInt32(&drop, 1)
+	defer atomic.StoreInt32(&drop, 0)
+
+	start := time.Now()
+	_, err = multiConn.Select(spaceNo, indexNo, 0, 1, tarantool.IterAll, []interface{}{})
+	require.NotNilf(t, err, "a hedged request is not timed out")
+	require.Less(t, time.Since(start), 2*connOpts.Timeout+time.Second)
+
+	clientErr, ok := err.(tarantool.ClientError)
+	require.Truef(t, ok, "unexpected error: %v", err)
+	require.Equal(t, uint32(tarantool.ErrTimeouted), clientErr.Code)
+}
+
+func TestUpdateReadOnly_Timeout(t *testing.T) {
+	var drop int32
+	opts := connOptsMulti
+	opts.CheckTimeout = 100 * time.Millisecond
+	opts.PerNodeOpts = map[string]tarantool.Opts{
+		server2: {Dialer: blackholeDialer{&drop}},
+	}
+
+	multiConn, err := ConnectWithOpts([]string{server1, server2}, connOpts, opts)
+	require.Nilf(t, err, "failed to connect")
+	require.NotNilf(t, multiConn, "conn is nil after Connect")
+	defer multiConn.Close()
+
+	atomic.StoreInt32(&drop, 1)
+	defer atomic.StoreInt32(&drop, 0)
+
+	start := time.Now()
+	multiConn.updateReadOnly()
+	require.Less(t, time.Since(start), connOpts.Timeout)
+
+	multiConn.mutex.RLock()
+	defer multiConn.mutex.RUnlock()
+	_, ok := multiConn.readOnly[server1]
+	require.True(t, ok)
+	_, ok = multiConn.readOnly[server2]
+	require.False(t, ok)
+}
+
+func TestBalancing_RoundRobin(t *testing.T) {
+	opts := connOptsMulti
+	opts.Balancing = RoundRobin
+
+	multiConn, err := ConnectWithOpts([]string{server1, server2}, connOpts, opts)
+	require.Nilf(t, err, "failed to connect")
+	require.NotNilf(t, multiConn, "conn is nil after Connect")
+	defer multiConn.Close()
+
+	listens := make(map[string]bool)
+	for i := 0; i < 2; i++ {
+		var listen []string
+		err = multiConn.EvalTyped("return box.cfg.listen", []interface{}{}, &listen)
+		require.Nilf(t, err, "failed to EvalTyped")
+		require.Equal(t, 1, len(listen))
+		listens[listen[0]] = true
+	}
+	require.Equal(t, 2, len(listens))
+}
+
+func TestDoMode(t *testing.T) {
+	multiConn, err := ConnectWithOpts([]string{server1, server2}, connOpts, connOptsMulti)
+	require.Nilf(t, err, "failed to connect")
+	require.NotNilf(t, multiConn, "conn is nil after Connect")
+	defer multiConn.Close()
+
+	_, err = multiConn.DoMode(tarantool.NewPingRequest(), RW).Get()
+	require.Nil(t, err)
+	_, err = multiConn.DoMode(tarantool.NewPingRequest(), RO).Get()
+	require.Equal(t, ErrNoRoInstance, err)
+	_, err = multiConn.DoMode(tarantool.NewPingRequest(), PreferRO).Get()
+	require.Nil(t, err)
+
+	conn := test_helpers.ConnectWithValidation(t, server2, connOpts)
+	defer conn.Close()
+	_, err = conn.Eval("box.cfg{read_only = true}", []interface{}{})
+	require.Nil(t, err)
+	defer conn.Eval("box.cfg{read_only = false}", []interface{}{})
+	multiConn.updateReadOnly()
+
+	modes := []Mode{RO, PreferRO}
+	for _, mode := range modes {
+		var ro []bool
+		err = multiConn.DoMode(tarantool.NewEvalRequest("return box.info.ro"),
+			mode).GetTyped(&ro)
+		require.Nil(t, err)
+		require.Equal(t, []bool{true}, ro)
+	}
+
+	modes = []Mode{RW, PreferRW}
+	for _, mode := range modes {
+		var ro []bool
+		err = multiConn.DoMode(tarantool.NewEvalRequest("return box.info.ro"),
+			mode).GetTyped(&ro)
+		require.Nil(t, err)
+		require.Equal(t, []bool{false}, ro)
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	opts := connOptsMulti
+	opts.ReadOnly = true
+
+	multiConn, err := ConnectWithOpts([]string{server1, server2}, connOpts, opts)
+	require.Nilf(t, err, "failed to connect")
+	require.NotNilf(t, multiConn, "conn is nil after Connect")
+	defer multiConn.Close()
+
+	tuple := []interface{}{uint(1001), "hello"}
+	ops := []interface{}{[]interface{}{"=", 1, "world"}}
+	key := []interface{}{uint(1001)}
+	var result []interface{}
+
+	_, err = multiConn.Insert(spaceNo, tuple)
+	require.Equal(t, ErrReadOnly, err)
+	_, err = multiConn.Replace(spaceNo, tuple)
+	require.Equal(t, ErrReadOnly, err)
+	_, err = multiConn.Delete(spaceNo, indexNo, key)
+	require.Equal(t, ErrReadOnly, err)
+	_, err = multiConn.Update(spaceNo, indexNo, key, ops)
+	require.Equal(t, ErrReadOnly, err)
+	_, err = multiConn.Upsert(spaceNo, tuple, ops)
+	require.Equal(t, ErrReadOnly, err)
+
+	err = multiConn.InsertTyped(spaceNo, tuple, &result)
+	require.Equal(t, ErrReadOnly, err)
+	err = multiConn.ReplaceTyped(spaceNo, tuple, &result)
+	require.Equal(t, ErrReadOnly, err)
+	err = multiConn.DeleteTyped(spaceNo, indexNo, key, &result)
+	require.Equal(t, ErrReadOnly, err)
+	err = multiConn.UpdateTyped(spaceNo, indexNo, key, ops, &result)
+	require.Equal(t, ErrReadOnly, err)
+
+	futures := []*tarantool.Future{
+		multiConn.InsertAsync(spaceNo, tuple),
+		multiConn.ReplaceAsync(spaceNo, tuple),
+		multiConn.DeleteAsync(spaceNo, indexNo, key),
+		multiConn.UpdateAsync(spaceNo, indexNo, key, ops),
+		multiConn.UpsertAsync(spaceNo, tuple, ops),
+		multiConn.Do(tarantool.NewInsertRequest(spaceNo).Tuple(tuple)),
+	}
+	for _, fut := range futures {
+		_, err = fut.Get()
+		require.Equal(t, ErrReadOnly, err)
+	}
+
+	_, err = multiConn.Select(spaceNo, indexNo, 0, 1, tarantool.IterEq, key)
+	require.Nil(t, err)
+	_, err = multiConn.Do(tarantool.NewSelectRequest(spaceNo)).Get()
+	require.Nil(t, err)
+}
+
+func TestBalancing_Random(t *testing.T) {
+	opts := connOptsMulti
+	opts.Balancing = Random
+
+	multiConn, err := ConnectWithOpts([]string{server1, server2}, connOpts, opts)
+	require.Nilf(t, err, "failed to connect")
+	require.NotNilf(t, multiConn, "conn is nil after Connect")
+	defer multiConn.Close()
+
+	listens := make(map[string]bool)
+	for i := 0; i < 50 && len(listens) < 2; i++ {
+		var listen []string
+		err = multiConn.EvalTyped("return box.cfg.listen", []interface{}{}, &listen)
+		require.Nilf(t, err, "failed to EvalTyped")
+		require.Equal(t, 1, len(listen))
+		listens[listen[0]] = true
+	}
+	require.Equal(t, 2, len(listens))
+}
+
+func TestBalancing_writes(t *testing.T) {
+	opts := connOptsMulti
+	opts.Balancing = RoundRobin
+
+	multiConn, err := ConnectWithOpts([]string{server1, server2}, connOpts, opts)
+	require.Nilf(t, err, "failed to connect")
+	require.NotNilf(t, multiConn, "conn is nil after Connect")
+	defer multiConn.Close()
+
+	key := []interface{}{"balancing_write"}
+	for i := 0; i < 4; i++ {
+		_, err = multiConn.Replace(spaceNo, []interface{}{"balancing_write", i})
+		require.Nilf(t, err, "failed to Replace")
+	}
+	defer multiConn.Delete(spaceNo, 0, key)
+
+	expected := map[string]int{
+		server1: 1,
+		server2: 0,
+	}
+	for addr, count := range expected {
+		conn := test_helpers.ConnectWithValidation(t, addr, connOpts)
+		defer conn.Close()
+
+		resp, err := conn.Select(spaceNo, 0, 0, 10, tarantool.IterEq, key)
+		require.Nilf(t, err, "failed to Select")
+		require.Lenf(t, resp.Data, count, "unexpected tuples on %s", addr)
+	}
+}
+
 func TestNewPrepared(t *testing.T) {
 	test_helpers.SkipIfSQLUnsupported(t)
 
@@ -622,6 +1180,78 @@ func runTestMain(m *testing.M) int {
 	return m.Run()
 }
 
+func TestEachConnection(t *testing.T) {
+	multiConn, err := Connect([]string{server1, server2}, connOpts)
+	require.Nil(t, err)
+	require.NotNil(t, multiConn)
+	defer multiConn.Close()
+
+	var addrs []string
+	err = multiConn.EachConnection(func(addr string, conn *tarantool.Connection) error {
+		addrs = append(addrs, addr)
+		_, err := conn.Ping()
+		return err
+	})
+	require.Nil(t, err)
+	require.Equal(t, []string{server1, server2}, addrs)
+
+	err = multiConn.EachConnection(func(addr string, conn *tarantool.Connection) error {
+		if addr == server2 {
+			return fmt.Errorf("failed")
+		}
+		return nil
+	})
+	require.NotNil(t, err)
+	eachErr, ok := err.(EachError)
+	require.True(t, ok, "unexpected error: %v", err)
+	require.Len(t, eachErr.Errors, 1)
+	require.EqualError(t, eachErr.Errors[server2], "failed")
+}
+
+func TestConnectionLabels(t *testing.T) {
+	labelOpts := connOpts
+	labelOpts.Label = "cluster"
+	multiConn, err := Connect([]string{server1, server2}, labelOpts)
+	require.Nil(t, err)
+	require.NotNil(t, multiConn)
+	defer multiConn.Close()
+
+	labels := make(map[string]string)
+	err = multiConn.EachConnection(func(addr string, conn *tarantool.Connection) error {
+		labels[addr] = conn.Label()
+		return nil
+	})
+	require.Nil(t, err)
+	require.Equal(t, map[string]string{
+		server1: "cluster/" + server1,
+		server2: "cluster/" + server2,
+	}, labels)
+}
+
+func TestConnectionLabels_perNode(t *testing.T) {
+	labelOpts := connOpts
+	labelOpts.Label = "cluster"
+	opts := connOptsMulti
+	opts.PerNodeOpts = map[string]tarantool.Opts{
+		server2: {Label: "replica"},
+	}
+	multiConn, err := ConnectWithOpts([]string{server1, server2}, labelOpts, opts)
+	require.Nil(t, err)
+	require.NotNil(t, multiConn)
+	defer multiConn.Close()
+
+	labels := make(map[string]string)
+	err = multiConn.EachConnection(func(addr string, conn *tarantool.Connection) error {
+		labels[addr] = conn.Label()
+		return nil
+	})
+	require.Nil(t, err)
+	require.Equal(t, map[string]string{
+		server1: "cluster/" + server1,
+		server2: "replica/" + server2,
+	}, labels)
+}
+
 func TestMain(m *testing.M) {
 	code := runTestMain(m)
 	os.Exit(code)
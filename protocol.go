@@ -49,6 +49,11 @@ const (
 	// PaginationFeature represents support of pagination
 	// (supported by connector).
 	PaginationFeature ProtocolFeature = 4
+	// SpaceAndIndexNamesFeature represents support of space and index names
+	// in requests instead of identifiers.
+	SpaceAndIndexNamesFeature ProtocolFeature = 5
+	// WatchOnceFeature represents support of IPROTO_WATCH_ONCE requests.
+	WatchOnceFeature ProtocolFeature = 6
 )
 
 // String returns the name of a Tarantool feature.
@@ -65,6 +70,10 @@ func (ftr ProtocolFeature) String() string {
 		return "WatchersFeature"
 	case PaginationFeature:
 		return "PaginationFeature"
+	case SpaceAndIndexNamesFeature:
+		return "SpaceAndIndexNamesFeature"
+	case WatchOnceFeature:
+		return "WatchOnceFeature"
 	default:
 		return fmt.Sprintf("Unknown feature (code %d)", ftr)
 	}
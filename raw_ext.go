@@ -0,0 +1,68 @@
+package tarantool
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// RawExt is a MP_EXT value of a type unknown to the connector: for example,
+// a type added in a newer Tarantool version. It keeps the value as is, so it
+// could be processed by an application code or sent back to Tarantool.
+//
+// Unknown extensions are decoded into interface{} values as RawExt only
+// with the msgpack.v5 library (the go_tarantool_msgpack_v5 build tag):
+// msgpack.v2 does not provide a length of an extension to decoders, so
+// such values still return an error. An extension registered by a user with
+// the msgpack library is decoded as the user type.
+type RawExt struct {
+	// Code is a type of the extension.
+	Code int8
+	// Data is a payload of the extension.
+	Data []byte
+}
+
+// EncodeMsgpack encodes the value as the MP_EXT with the type and the
+// payload.
+func (ext RawExt) EncodeMsgpack(e *encoder) error {
+	header, err := extHeader(ext.Code, len(ext.Data))
+	if err != nil {
+		return err
+	}
+	w := e.Writer()
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	_, err = w.Write(ext.Data)
+	return err
+}
+
+// extHeader returns a MP_EXT header for a payload with the length.
+func extHeader(code int8, l int) ([]byte, error) {
+	switch l {
+	case 1:
+		return []byte{0xd4, byte(code)}, nil
+	case 2:
+		return []byte{0xd5, byte(code)}, nil
+	case 4:
+		return []byte{0xd6, byte(code)}, nil
+	case 8:
+		return []byte{0xd7, byte(code)}, nil
+	case 16:
+		return []byte{0xd8, byte(code)}, nil
+	}
+
+	switch {
+	case l <= math.MaxUint8:
+		return []byte{0xc7, byte(l), byte(code)}, nil
+	case l <= math.MaxUint16:
+		header := []byte{0xc8, 0, 0, byte(code)}
+		binary.BigEndian.PutUint16(header[1:], uint16(l))
+		return header, nil
+	case uint64(l) <= math.MaxUint32:
+		header := []byte{0xc9, 0, 0, 0, 0, byte(code)}
+		binary.BigEndian.PutUint32(header[1:], uint32(l))
+		return header, nil
+	}
+	return nil, fmt.Errorf("RawExt: too long payload %d", l)
+}
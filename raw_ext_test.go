@@ -0,0 +1,65 @@
+package tarantool_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/tarantool/go-tarantool"
+)
+
+func TestRawExt_Encode(t *testing.T) {
+	cases := []struct {
+		ext      RawExt
+		expected []byte
+	}{
+		{RawExt{Code: 100, Data: []byte{1}}, []byte{0xd4, 100, 1}},
+		{RawExt{Code: 100, Data: []byte{1, 2, 3, 4}}, []byte{0xd6, 100, 1, 2, 3, 4}},
+		{RawExt{Code: -5, Data: []byte{1, 2, 3}}, []byte{0xc7, 3, 0xfb, 1, 2, 3}},
+		{RawExt{Code: 100, Data: []byte{}}, []byte{0xc7, 0, 100}},
+		{
+			RawExt{Code: 100, Data: bytes.Repeat([]byte{1}, 300)},
+			append([]byte{0xc8, 0x01, 0x2c, 100}, bytes.Repeat([]byte{1}, 300)...),
+		},
+	}
+
+	for _, tc := range cases {
+		data, err := marshal(tc.ext)
+		require.Nil(t, err)
+		require.Equal(t, tc.expected, data)
+	}
+}
+
+func TestRawExt_Decode(t *testing.T) {
+	data, err := marshal([]interface{}{RawExt{Code: 100, Data: []byte{1, 2, 3}}})
+	require.Nil(t, err)
+
+	var decoded []interface{}
+	err = unmarshal(data, &decoded)
+	if !rawExtDecodingSupported {
+		require.NotNil(t, err)
+		return
+	}
+	require.Nil(t, err)
+	require.Equal(t, []interface{}{RawExt{Code: 100, Data: []byte{1, 2, 3}}},
+		decoded)
+}
+
+func TestRawExt_DecodeTimestamp(t *testing.T) {
+	if !rawExtDecodingSupported {
+		t.Skip("RawExt decoding is not supported")
+	}
+
+	tm := time.Unix(1, 2).UTC()
+	data, err := marshal([]interface{}{tm})
+	require.Nil(t, err)
+
+	var decoded []interface{}
+	err = unmarshal(data, &decoded)
+	require.Nil(t, err)
+	require.Len(t, decoded, 1)
+	require.IsType(t, time.Time{}, decoded[0])
+	require.True(t, tm.Equal(decoded[0].(time.Time)))
+}
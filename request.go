@@ -1,25 +1,106 @@
 package tarantool
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"reflect"
 	"strings"
 	"sync"
+	"time"
 )
 
-func fillSearch(enc *encoder, spaceNo, indexNo uint32, key interface{}) error {
-	if err := encodeUint(enc, KeySpaceNo); err != nil {
+// spaceEncoder encodes a space identifier: a number or a name.
+type spaceEncoder struct {
+	Id   uint32
+	Name string
+	IsId bool
+}
+
+// Encode encodes the space key and value.
+func (e spaceEncoder) Encode(enc *encoder) error {
+	if e.IsId {
+		if err := encodeUint(enc, KeySpaceNo); err != nil {
+			return err
+		}
+		return encodeUint(enc, uint64(e.Id))
+	}
+	if err := encodeUint(enc, KeySpaceName); err != nil {
 		return err
 	}
-	if err := encodeUint(enc, uint64(spaceNo)); err != nil {
+	return enc.EncodeString(e.Name)
+}
+
+// indexEncoder encodes an index identifier: a number or a name.
+type indexEncoder struct {
+	Id   uint32
+	Name string
+	IsId bool
+}
+
+// Encode encodes the index key and value.
+func (e indexEncoder) Encode(enc *encoder) error {
+	if e.IsId {
+		if err := encodeUint(enc, KeyIndexNo); err != nil {
+			return err
+		}
+		return encodeUint(enc, uint64(e.Id))
+	}
+	if err := encodeUint(enc, KeyIndexName); err != nil {
 		return err
 	}
-	if err := encodeUint(enc, KeyIndexNo); err != nil {
+	return enc.EncodeString(e.Name)
+}
+
+// newSpaceIndexEncoders creates encoders for the space and the index. Names
+// are kept as is if the resolver supports it, otherwise they are resolved
+// into numbers.
+func newSpaceIndexEncoders(res SchemaResolver, space,
+	index interface{}) (spaceEncoder, indexEncoder, error) {
+	var spaceEnc spaceEncoder
+	var indexEnc indexEncoder
+
+	names := false
+	if namesRes, ok := res.(NamesSchemaResolver); ok {
+		names = namesRes.NamesUseSupported()
+	}
+
+	spaceName, spaceIsName := space.(string)
+	indexName, indexIsName := index.(string)
+	if names && spaceIsName {
+		spaceEnc.Name = spaceName
+		// The number is not used.
+		space = uint32(0)
+	} else {
+		spaceEnc.IsId = true
+	}
+	if names && indexIsName {
+		indexEnc.Name = indexName
+		index = nil
+	} else {
+		indexEnc.IsId = true
+	}
+	if !spaceEnc.IsId && index == nil {
+		return spaceEnc, indexEnc, nil
+	}
+
+	spaceNo, indexNo, err := res.ResolveSpaceIndex(space, index)
+	if err != nil {
+		return spaceEnc, indexEnc, err
+	}
+	spaceEnc.Id = spaceNo
+	indexEnc.Id = indexNo
+	return spaceEnc, indexEnc, nil
+}
+
+func fillSearch(enc *encoder, spaceEnc spaceEncoder, indexEnc indexEncoder,
+	key interface{}) error {
+	if err := spaceEnc.Encode(enc); err != nil {
 		return err
 	}
-	if err := encodeUint(enc, uint64(indexNo)); err != nil {
+	if err := indexEnc.Encode(enc); err != nil {
 		return err
 	}
 	if err := encodeUint(enc, KeyKey); err != nil {
@@ -47,24 +128,21 @@ func fillIterator(enc *encoder, offset, limit, iterator uint32) error {
 	return encodeUint(enc, uint64(limit))
 }
 
-func fillInsert(enc *encoder, spaceNo uint32, tuple interface{}) error {
+func fillInsert(enc *encoder, spaceEnc spaceEncoder, tuple interface{}) error {
 	if err := enc.EncodeMapLen(2); err != nil {
 		return err
 	}
-	if err := encodeUint(enc, KeySpaceNo); err != nil {
-		return err
-	}
-	if err := encodeUint(enc, uint64(spaceNo)); err != nil {
+	if err := spaceEnc.Encode(enc); err != nil {
 		return err
 	}
 	if err := encodeUint(enc, KeyTuple); err != nil {
 		return err
 	}
-	return enc.Encode(tuple)
+	return encodeTuple(enc, tuple)
 }
 
-func fillSelect(enc *encoder, spaceNo, indexNo, offset, limit, iterator uint32,
-	key, after interface{}, fetchPos bool) error {
+func fillSelect(enc *encoder, spaceEnc spaceEncoder, indexEnc indexEncoder,
+	offset, limit, iterator uint32, key, after interface{}, fetchPos bool) error {
 	mapLen := 6
 	if fetchPos {
 		mapLen += 1
@@ -78,7 +156,7 @@ func fillSelect(enc *encoder, spaceNo, indexNo, offset, limit, iterator uint32,
 	if err := fillIterator(enc, offset, limit, iterator); err != nil {
 		return err
 	}
-	if err := fillSearch(enc, spaceNo, indexNo, key); err != nil {
+	if err := fillSearch(enc, spaceEnc, indexEnc, key); err != nil {
 		return err
 	}
 	if fetchPos {
@@ -109,30 +187,33 @@ func fillSelect(enc *encoder, spaceNo, indexNo, offset, limit, iterator uint32,
 	return nil
 }
 
-func fillUpdate(enc *encoder, spaceNo, indexNo uint32, key, ops interface{}) error {
+func fillUpdate(enc *encoder, spaceEnc spaceEncoder, indexEnc indexEncoder,
+	key, ops interface{}) error {
 	enc.EncodeMapLen(4)
-	if err := fillSearch(enc, spaceNo, indexNo, key); err != nil {
+	if err := fillSearch(enc, spaceEnc, indexEnc, key); err != nil {
 		return err
 	}
 	encodeUint(enc, KeyTuple)
 	return enc.Encode(ops)
 }
 
-func fillUpsert(enc *encoder, spaceNo uint32, tuple, ops interface{}) error {
+func fillUpsert(enc *encoder, spaceEnc spaceEncoder, tuple, ops interface{}) error {
 	enc.EncodeMapLen(3)
-	encodeUint(enc, KeySpaceNo)
-	encodeUint(enc, uint64(spaceNo))
+	if err := spaceEnc.Encode(enc); err != nil {
+		return err
+	}
 	encodeUint(enc, KeyTuple)
-	if err := enc.Encode(tuple); err != nil {
+	if err := encodeTuple(enc, tuple); err != nil {
 		return err
 	}
 	encodeUint(enc, KeyDefTuple)
 	return enc.Encode(ops)
 }
 
-func fillDelete(enc *encoder, spaceNo, indexNo uint32, key interface{}) error {
+func fillDelete(enc *encoder, spaceEnc spaceEncoder, indexEnc indexEncoder,
+	key interface{}) error {
 	enc.EncodeMapLen(3)
-	return fillSearch(enc, spaceNo, indexNo, key)
+	return fillSearch(enc, spaceEnc, indexEnc, key)
 }
 
 func fillCall(enc *encoder, functionName string, args interface{}) error {
@@ -168,6 +249,42 @@ func (conn *Connection) Ping() (resp *Response, err error) {
 	return conn.Do(NewPingRequest()).Get()
 }
 
+// PingTimed sends empty request to Tarantool and returns a round-trip time
+// of the request. The request is limited by Opts.Timeout as other requests.
+func (conn *Connection) PingTimed() (time.Duration, error) {
+	start := time.Now()
+	if _, err := conn.Do(NewPingRequest()).Get(); err != nil {
+		return 0, err
+	}
+	return time.Since(start), nil
+}
+
+// IsReadOnly returns true if the connected instance is in read-only mode
+// (box.info.ro). The value is requested from the instance on each call.
+func (conn *Connection) IsReadOnly() (bool, error) {
+	var ro []bool
+	if err := conn.EvalTyped("return box.info.ro", []interface{}{}, &ro); err != nil {
+		return false, err
+	}
+	if len(ro) == 0 {
+		return false, errors.New("unexpected response: no data")
+	}
+	return ro[0], nil
+}
+
+// Info returns information about the connected instance from box.info.
+// The value is requested from the instance on each call.
+func (conn *Connection) Info() (BoxInfo, error) {
+	var info []BoxInfo
+	if err := conn.Call17Typed("box.info", []interface{}{}, &info); err != nil {
+		return BoxInfo{}, err
+	}
+	if len(info) == 0 {
+		return BoxInfo{}, errors.New("unexpected response: no data")
+	}
+	return info[0], nil
+}
+
 // Select performs select to box space.
 //
 // It is equal to conn.SelectAsync(...).Get().
@@ -258,6 +375,80 @@ func (conn *Connection) Execute(expr string, args interface{}) (resp *Response,
 	return conn.ExecuteAsync(expr, args).Get()
 }
 
+// InsertMany performs insertion of the tuples to box space.
+//
+// If stopOnError is false, all requests are sent as a pipelined batch and
+// the result contains a response or an error for each tuple. Otherwise,
+// a next tuple is sent only after a successful insertion of the previous
+// one and the rest of tuples are not sent after the first error.
+//
+// The error is BatchError if at least one insertion failed. Responses for
+// failed or not sent tuples are nil.
+func (conn *Connection) InsertMany(space interface{}, tuples []interface{},
+	stopOnError bool) ([]*Response, error) {
+	return doMany(conn.Do, tuples, stopOnError, func(tuple interface{}) Request {
+		return NewInsertRequest(space).Tuple(tuple)
+	})
+}
+
+// ReplaceMany performs "insert or replace" action of the tuples to box
+// space. It works the same way as InsertMany.
+func (conn *Connection) ReplaceMany(space interface{}, tuples []interface{},
+	stopOnError bool) ([]*Response, error) {
+	return doMany(conn.Do, tuples, stopOnError, func(tuple interface{}) Request {
+		return NewReplaceRequest(space).Tuple(tuple)
+	})
+}
+
+// DeleteMany performs deletion of tuples by the keys from box space.
+// It works the same way as InsertMany. A response data is empty if there
+// is no tuple with the key, otherwise it contains the deleted tuple.
+//
+// Use Stream.DeleteMany inside a transaction to delete the keys atomically.
+func (conn *Connection) DeleteMany(space, index interface{}, keys []interface{},
+	stopOnError bool) ([]*Response, error) {
+	return doMany(conn.Do, keys, stopOnError, deleteManyRequest(space, index))
+}
+
+func deleteManyRequest(space, index interface{}) func(key interface{}) Request {
+	return func(key interface{}) Request {
+		return NewDeleteRequest(space).Index(index).Key(key)
+	}
+}
+
+func doMany(do func(req Request) *Future, tuples []interface{}, stopOnError bool,
+	newRequest func(tuple interface{}) Request) ([]*Response, error) {
+	resps := make([]*Response, len(tuples))
+	errs := make([]error, len(tuples))
+	failed := false
+
+	if stopOnError {
+		for i, tuple := range tuples {
+			if resps[i], errs[i] = do(newRequest(tuple)).Get(); errs[i] != nil {
+				resps[i] = nil
+				failed = true
+				break
+			}
+		}
+	} else {
+		futures := make([]*Future, len(tuples))
+		for i, tuple := range tuples {
+			futures[i] = do(newRequest(tuple))
+		}
+		for i, fut := range futures {
+			if resps[i], errs[i] = fut.Get(); errs[i] != nil {
+				resps[i] = nil
+				failed = true
+			}
+		}
+	}
+
+	if failed {
+		return resps, BatchError{Errors: errs}
+	}
+	return resps, nil
+}
+
 // single used for conn.GetTyped for decode one tuple.
 type single struct {
 	res   interface{}
@@ -290,12 +481,73 @@ func (conn *Connection) GetTyped(space, index interface{}, key interface{}, resu
 }
 
 // SelectTyped performs select to box space and fills typed result.
+// The result could be a pointer to a slice of structs or a slice of pointers
+// to structs, in the last case each element is allocated by the decoder.
 //
 // It is equal to conn.SelectAsync(space, index, offset, limit, iterator, key).GetTyped(&result)
 func (conn *Connection) SelectTyped(space, index interface{}, offset, limit, iterator uint32, key interface{}, result interface{}) (err error) {
 	return conn.SelectAsync(space, index, offset, limit, iterator, key).GetTyped(result)
 }
 
+// SelectEach performs select to box space and calls the function for each
+// selected tuple. The decoder is positioned at the tuple and the function
+// must decode the whole tuple, for example, with d.Decode(&value). It allows
+// to process a large result without decoding it into a slice.
+//
+// The iteration stops on the first error returned by the function and the
+// error is returned.
+func (conn *Connection) SelectEach(space, index interface{},
+	offset, limit, iterator uint32, key interface{},
+	fn func(d *decoder) error) error {
+	return conn.SelectAsync(space, index, offset, limit, iterator, key).
+		GetTyped(&eachTuple{fn})
+}
+
+// eachTuple decodes an array of tuples with a callback per tuple.
+type eachTuple struct {
+	fn func(d *decoder) error
+}
+
+func (each *eachTuple) DecodeMsgpack(d *decoder) error {
+	l, err := d.DecodeArrayLen()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < l; i++ {
+		if err := each.fn(d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SelectTypedPos performs select to box space, fills typed result and
+// returns a position of the last selected tuple. The select continues after
+// the position if it is not empty, so the returned position could be passed
+// as after to get a next page.
+//
+// Requires Tarantool >= 2.11.
+func (conn *Connection) SelectTypedPos(space, index interface{},
+	offset, limit, iterator uint32, key interface{}, after []byte,
+	result interface{}) (pos []byte, err error) {
+	req := NewSelectRequest(space).
+		Index(index).
+		Offset(offset).
+		Limit(limit).
+		Iterator(iterator).
+		Key(key).
+		FetchPos(true)
+	if len(after) > 0 {
+		req.After(after)
+	}
+
+	fut := conn.Do(req)
+	if err = fut.GetTyped(result); err != nil {
+		return nil, err
+	}
+	return fut.resp.Pos, nil
+}
+
 // InsertTyped performs insertion to box space.
 // Tarantool will reject Insert when tuple with same primary key exists.
 //
@@ -354,6 +606,41 @@ func (conn *Connection) Call17Typed(functionName string, args interface{}, resul
 	return conn.Call17Async(functionName, args).GetTyped(result)
 }
 
+// first used for conn.Call17First for decode the first returned value.
+type first struct {
+	res interface{}
+}
+
+func (f *first) DecodeMsgpack(d *decoder) error {
+	l, err := d.DecodeArrayLen()
+	if err != nil {
+		return err
+	}
+	if l < 1 {
+		return errors.New("the function returns no values")
+	}
+	if err = d.Decode(f.res); err != nil {
+		return err
+	}
+	for i := 1; i < l; i++ {
+		if err = d.Skip(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Call17First calls registered function and fills the result with the first
+// returned value. Other values are skipped. An error is returned if
+// the function returns nothing.
+//
+// It is equal to conn.Call17Typed(functionName, args, &result) where
+// the result is an array with a single element.
+func (conn *Connection) Call17First(functionName string, args interface{}, result interface{}) error {
+	f := first{res: result}
+	return conn.Call17Async(functionName, args).GetTyped(&f)
+}
+
 // EvalTyped passes Lua expression for evaluation.
 //
 // It is equal to conn.EvalAsync(space, tuple).GetTyped(&result).
@@ -361,6 +648,42 @@ func (conn *Connection) EvalTyped(expr string, args interface{}, result interfac
 	return conn.EvalAsync(expr, args).GetTyped(result)
 }
 
+// positional used for conn.EvalMulti for decode returned values into
+// results in order.
+type positional struct {
+	res []interface{}
+}
+
+func (p *positional) DecodeMsgpack(d *decoder) error {
+	l, err := d.DecodeArrayLen()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < l; i++ {
+		if i < len(p.res) && p.res[i] != nil {
+			err = d.Decode(p.res[i])
+		} else {
+			err = d.Skip()
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EvalMulti passes Lua expression for evaluation and fills the results with
+// returned values in order. Extra values and values for nil results are
+// skipped, results without values are not changed.
+//
+//	var res string
+//	var errMsg *string
+//	err := conn.EvalMulti("return func(...)", args, &res, &errMsg)
+func (conn *Connection) EvalMulti(expr string, args interface{}, results ...interface{}) error {
+	p := positional{res: results}
+	return conn.EvalAsync(expr, args).GetTyped(&p)
+}
+
 // ExecuteTyped passes sql expression to Tarantool for execution.
 //
 // In addition to error returns sql info and columns meta data
@@ -371,6 +694,114 @@ func (conn *Connection) ExecuteTyped(expr string, args interface{}, result inter
 	return fut.resp.SQLInfo, fut.resp.MetaData, err
 }
 
+// ExecuteTypedByName passes sql expression to Tarantool for execution and
+// fills the result by column names from the response meta data.
+//
+// The result must be a pointer to a slice of structs or pointers to
+// structs. A column is mapped to a struct field with the same name in the
+// "db" or "msgpack" tag or, if there is no tag, with the same field name.
+// Names are compared case-insensitively. Columns without a matching field
+// are skipped.
+func (conn *Connection) ExecuteTypedByName(expr string, args interface{}, result interface{}) (SQLInfo, []ColumnMetaData, error) {
+	resp, err := conn.Execute(expr, args)
+	if resp == nil {
+		return SQLInfo{}, nil, err
+	}
+	if err == nil {
+		err = decodeRowsByName(resp.MetaData, resp.Data, result)
+	}
+	return resp.SQLInfo, resp.MetaData, err
+}
+
+// fieldsByColumns returns indexes of the struct fields for the columns. It
+// is -1 for columns without a matching field.
+func fieldsByColumns(typ reflect.Type, columns []ColumnMetaData) []int {
+	names := make(map[string]int, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.PkgPath != "" {
+			// Unexported field.
+			continue
+		}
+		name := field.Tag.Get("db")
+		if name == "" {
+			name = strings.Split(field.Tag.Get("msgpack"), ",")[0]
+		}
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names[strings.ToLower(name)] = i
+	}
+
+	fields := make([]int, len(columns))
+	for i, column := range columns {
+		if field, ok := names[strings.ToLower(column.FieldName)]; ok {
+			fields[i] = field
+		} else {
+			fields[i] = -1
+		}
+	}
+	return fields
+}
+
+func decodeRowsByName(columns []ColumnMetaData, rows []interface{}, result interface{}) error {
+	ptr := reflect.ValueOf(result)
+	if ptr.Kind() != reflect.Ptr || ptr.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("result must be a pointer to a slice, got %T", result)
+	}
+	slice := ptr.Elem()
+	elemType := slice.Type().Elem()
+	structType := elemType
+	if elemType.Kind() == reflect.Ptr {
+		structType = elemType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return fmt.Errorf("result must be a pointer to a slice of structs, got %T",
+			result)
+	}
+
+	fields := fieldsByColumns(structType, columns)
+
+	var buf bytes.Buffer
+	enc := newEncoder(&buf)
+	dec := newDecoder(&buf)
+
+	slice.Set(reflect.MakeSlice(slice.Type(), 0, len(rows)))
+	for _, row := range rows {
+		values, ok := row.([]interface{})
+		if !ok {
+			return fmt.Errorf("unexpected row type %T", row)
+		}
+
+		elem := reflect.New(structType).Elem()
+		for i, value := range values {
+			if i >= len(fields) || fields[i] < 0 {
+				continue
+			}
+			// Decode the value into the field with msgpack rules.
+			buf.Reset()
+			if err := enc.Encode(value); err != nil {
+				return err
+			}
+			field := elem.Field(fields[i])
+			if err := dec.Decode(field.Addr().Interface()); err != nil {
+				return fmt.Errorf("failed to decode column %s: %w",
+					columns[i].FieldName, err)
+			}
+		}
+
+		if elemType.Kind() == reflect.Ptr {
+			slice.Set(reflect.Append(slice, elem.Addr()))
+		} else {
+			slice.Set(reflect.Append(slice, elem))
+		}
+	}
+	return nil
+}
+
 // SelectAsync sends select request to Tarantool and returns Future.
 func (conn *Connection) SelectAsync(space, index interface{}, offset, limit, iterator uint32, key interface{}) *Future {
 	req := NewSelectRequest(space).
@@ -611,11 +1042,25 @@ type Request interface {
 	Async() bool
 }
 
+// EncodeRequest encodes a body of the request into msgpack and returns
+// the bytes. The resolver is used to resolve space and index names, it could
+// be nil if the request does not contain names. A request header is not
+// encoded.
+func EncodeRequest(req Request, res SchemaResolver) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := req.Body(res, newEncoder(&buf)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
 // ConnectedRequest is an interface that provides the info about a Connection
 // the request belongs to.
 type ConnectedRequest interface {
 	Request
-	// Conn returns a Connection the request belongs to.
+	// Conn returns a Connection the request belongs to. A wrapper like
+	// SchemaVersionRequest returns nil if the wrapped request does not
+	// belong to a Connection.
 	Conn() *Connection
 }
 
@@ -649,6 +1094,10 @@ func (req *spaceRequest) setSpace(space interface{}) {
 	req.space = space
 }
 
+func (req *spaceRequest) schemaSpaceIndex() (space, index interface{}) {
+	return req.space, nil
+}
+
 type spaceIndexRequest struct {
 	spaceRequest
 	index interface{}
@@ -658,6 +1107,10 @@ func (req *spaceIndexRequest) setIndex(index interface{}) {
 	req.index = index
 }
 
+func (req *spaceIndexRequest) schemaSpaceIndex() (space, index interface{}) {
+	return req.space, req.index
+}
+
 // authRequest implements IPROTO_AUTH request.
 type authRequest struct {
 	auth       Auth
@@ -748,6 +1201,9 @@ type SelectRequest struct {
 	isIteratorSet, fetchPos bool
 	offset, limit, iterator uint32
 	key, after              interface{}
+	pageErr                 error
+	// byField is a name of a field to find the index with the schema.
+	byField string
 }
 
 // NewSelectRequest returns a new empty SelectRequest.
@@ -760,7 +1216,7 @@ func NewSelectRequest(space interface{}) *SelectRequest {
 	req.iterator = IterAll
 	req.key = []interface{}{}
 	req.after = nil
-	req.limit = 0xFFFFFFFF
+	req.limit = math.MaxUint32
 	return req
 }
 
@@ -779,12 +1235,21 @@ func (req *SelectRequest) Offset(offset uint32) *SelectRequest {
 }
 
 // Limit sets the limit for the select request.
-// Note: default value is 0xFFFFFFFF.
+// Note: default value is 0xFFFFFFFF. Tarantool treats the zero limit as
+// zero tuples, use Unlimited() to select all tuples.
 func (req *SelectRequest) Limit(limit uint32) *SelectRequest {
 	req.limit = limit
 	return req
 }
 
+// Unlimited sets the maximum limit for the select request, so all tuples
+// matching the key are selected. It is the default and differs from
+// Limit(0) that selects no tuples.
+func (req *SelectRequest) Unlimited() *SelectRequest {
+	req.limit = math.MaxUint32
+	return req
+}
+
 // Iterator set the iterator for the select request.
 // Note: default value is IterAll if key is not set or IterEq otherwise.
 func (req *SelectRequest) Iterator(iterator uint32) *SelectRequest {
@@ -793,7 +1258,8 @@ func (req *SelectRequest) Iterator(iterator uint32) *SelectRequest {
 	return req
 }
 
-// Key set the key for the select request.
+// Key set the key for the select request. A number of CompositeKey parts
+// is validated against the index if the schema is loaded.
 // Note: default value is empty.
 func (req *SelectRequest) Key(key interface{}) *SelectRequest {
 	req.key = key
@@ -803,6 +1269,40 @@ func (req *SelectRequest) Key(key interface{}) *SelectRequest {
 	return req
 }
 
+// Page sets the index, the iterator, the key, the offset and the limit to
+// select a page of pageSize tuples starting from the offset-th tuple in the
+// order of the iterator. The order is descending for IterReq, IterLt and
+// IterLe, so the offset is counted from the key down to the beginning of the
+// index for them, for example, the second page of 10 tuples with keys less
+// than or equal to 100 are tuples with keys 90..81 for sequential keys.
+//
+// An empty result is returned if the offset is beyond the end of the
+// matching tuples. The request returns an error if the pageSize is zero or
+// the iterator does not define an order of tuples: bitset iterators are not
+// supported.
+func (req *SelectRequest) Page(index interface{}, iterator uint32, key interface{},
+	pageSize, offset uint32) *SelectRequest {
+	req.pageErr = nil
+	if pageSize == 0 {
+		req.pageErr = fmt.Errorf("page size must be greater than zero")
+	} else if iterator > IterGt {
+		req.pageErr = fmt.Errorf("iterator %d does not support pagination",
+			iterator)
+	}
+	return req.Index(index).Iterator(iterator).Key(key).Offset(offset).
+		Limit(pageSize)
+}
+
+// ByField sets the key to select tuples with the value of the field. The
+// field is a name from the space format and the index is an index which
+// first part is the field, see Space.IndexByField. The index is found with
+// the schema when the request is encoded, so the schema must be loaded. It
+// overrides the index and the key set before.
+func (req *SelectRequest) ByField(field string, value interface{}) *SelectRequest {
+	req.byField = field
+	return req.Key([]interface{}{value})
+}
+
 // FetchPos determines whether to fetch positions of the last tuple. A position
 // descriptor will be saved in Response.Pos value.
 //
@@ -829,12 +1329,37 @@ func (req *SelectRequest) After(after interface{}) *SelectRequest {
 
 // Body fills an encoder with the select request body.
 func (req *SelectRequest) Body(res SchemaResolver, enc *encoder) error {
-	spaceNo, indexNo, err := res.ResolveSpaceIndex(req.space, req.index)
+	if req.pageErr != nil {
+		return req.pageErr
+	}
+	index := req.index
+	if req.byField != "" {
+		schema := resolverSchema(res)
+		if schema == nil {
+			return fmt.Errorf("unable to find an index by field %s: "+
+				"the schema is not loaded", req.byField)
+		}
+		space, err := schema.Space(req.space)
+		if err != nil {
+			return err
+		}
+		found, err := space.IndexByField(req.byField)
+		if err != nil {
+			return err
+		}
+		index = found.Id
+	}
+	spaceEnc, indexEnc, err := newSpaceIndexEncoders(res, req.space, index)
 	if err != nil {
 		return err
 	}
+	if key, ok := req.key.(CompositeKey); ok {
+		if err := key.validate(res, req.space, index); err != nil {
+			return err
+		}
+	}
 
-	return fillSelect(enc, spaceNo, indexNo, req.offset, req.limit, req.iterator,
+	return fillSelect(enc, spaceEnc, indexEnc, req.offset, req.limit, req.iterator,
 		req.key, req.after, req.fetchPos)
 }
 
@@ -874,12 +1399,12 @@ func (req *InsertRequest) Tuple(tuple interface{}) *InsertRequest {
 
 // Body fills an encoder with the insert request body.
 func (req *InsertRequest) Body(res SchemaResolver, enc *encoder) error {
-	spaceNo, _, err := res.ResolveSpaceIndex(req.space, nil)
+	spaceEnc, _, err := newSpaceIndexEncoders(res, req.space, nil)
 	if err != nil {
 		return err
 	}
 
-	return fillInsert(enc, spaceNo, req.tuple)
+	return fillInsert(enc, spaceEnc, req.tuple)
 }
 
 // Context sets a passed context to the request.
@@ -918,12 +1443,12 @@ func (req *ReplaceRequest) Tuple(tuple interface{}) *ReplaceRequest {
 
 // Body fills an encoder with the replace request body.
 func (req *ReplaceRequest) Body(res SchemaResolver, enc *encoder) error {
-	spaceNo, _, err := res.ResolveSpaceIndex(req.space, nil)
+	spaceEnc, _, err := newSpaceIndexEncoders(res, req.space, nil)
 	if err != nil {
 		return err
 	}
 
-	return fillInsert(enc, spaceNo, req.tuple)
+	return fillInsert(enc, spaceEnc, req.tuple)
 }
 
 // Context sets a passed context to the request.
@@ -969,12 +1494,12 @@ func (req *DeleteRequest) Key(key interface{}) *DeleteRequest {
 
 // Body fills an encoder with the delete request body.
 func (req *DeleteRequest) Body(res SchemaResolver, enc *encoder) error {
-	spaceNo, indexNo, err := res.ResolveSpaceIndex(req.space, req.index)
+	spaceEnc, indexEnc, err := newSpaceIndexEncoders(res, req.space, req.index)
 	if err != nil {
 		return err
 	}
 
-	return fillDelete(enc, spaceNo, indexNo, req.key)
+	return fillDelete(enc, spaceEnc, indexEnc, req.key)
 }
 
 // Context sets a passed context to the request.
@@ -1031,12 +1556,12 @@ func (req *UpdateRequest) Operations(ops *Operations) *UpdateRequest {
 
 // Body fills an encoder with the update request body.
 func (req *UpdateRequest) Body(res SchemaResolver, enc *encoder) error {
-	spaceNo, indexNo, err := res.ResolveSpaceIndex(req.space, req.index)
+	spaceEnc, indexEnc, err := newSpaceIndexEncoders(res, req.space, req.index)
 	if err != nil {
 		return err
 	}
 
-	return fillUpdate(enc, spaceNo, indexNo, req.key, req.ops)
+	return fillUpdate(enc, spaceEnc, indexEnc, req.key, req.ops)
 }
 
 // Context sets a passed context to the request.
@@ -1054,8 +1579,9 @@ func (req *UpdateRequest) Context(ctx context.Context) *UpdateRequest {
 // by a Connection.
 type UpsertRequest struct {
 	spaceRequest
-	tuple interface{}
-	ops   interface{}
+	tuple        interface{}
+	ops          interface{}
+	incrementErr error
 }
 
 // NewUpsertRequest returns a new empty UpsertRequest.
@@ -1084,14 +1610,46 @@ func (req *UpsertRequest) Operations(ops *Operations) *UpsertRequest {
 	return req
 }
 
+// Increment sets the tuple and the operations to increment the field by
+// the delta. If there is no tuple with the same primary key, the tuple is
+// inserted with the field set to the delta, so the counter starts from zero
+// on both insert and update paths. The tuple is padded with nils if it is
+// shorter than the field number. The field number starts from 0. The request
+// returns an error if the field number is negative.
+//
+// Increment replaces the tuple and operations set before.
+func (req *UpsertRequest) Increment(field int, delta interface{},
+	tuple []interface{}) *UpsertRequest {
+	req.incrementErr = nil
+	if field < 0 {
+		req.incrementErr = fmt.Errorf("invalid field number %d to increment",
+			field)
+		return req
+	}
+	size := len(tuple)
+	if field >= size {
+		size = field + 1
+	}
+	inserted := make([]interface{}, size)
+	copy(inserted, tuple)
+	inserted[field] = delta
+
+	req.tuple = inserted
+	req.ops = NewOperations().Add(field, delta).ops
+	return req
+}
+
 // Body fills an encoder with the upsert request body.
 func (req *UpsertRequest) Body(res SchemaResolver, enc *encoder) error {
-	spaceNo, _, err := res.ResolveSpaceIndex(req.space, nil)
+	if req.incrementErr != nil {
+		return req.incrementErr
+	}
+	spaceEnc, _, err := newSpaceIndexEncoders(res, req.space, nil)
 	if err != nil {
 		return err
 	}
 
-	return fillUpsert(enc, spaceNo, req.tuple, req.ops)
+	return fillUpsert(enc, spaceEnc, req.tuple, req.ops)
 }
 
 // Context sets a passed context to the request.
@@ -1105,6 +1663,18 @@ func (req *UpsertRequest) Context(ctx context.Context) *UpsertRequest {
 	return req
 }
 
+// CallFormat is a format of results of a call request.
+type CallFormat int
+
+const (
+	// Call16 is the Tarantool 1.6 format: each returned value is converted
+	// to a tuple and the tuples are returned as an array.
+	Call16 CallFormat = iota
+	// Call17 is the Tarantool >= 1.7 format: returned values are returned
+	// as an array as is.
+	Call17
+)
+
 // CallRequest helps you to create a call request object for execution
 // by a Connection.
 type CallRequest struct {
@@ -1130,6 +1700,20 @@ func (req *CallRequest) Args(args interface{}) *CallRequest {
 	return req
 }
 
+// Format sets the format of results for the call request. It allows to
+// call functions expecting different formats with the same binary
+// regardless of the go_tarantool_call_17 build tag.
+// Note: default value is Call17 if go-tarantool was build with
+// go_tarantool_call_17 tag, otherwise it is Call16.
+func (req *CallRequest) Format(format CallFormat) *CallRequest {
+	if format == Call16 {
+		req.requestCode = Call16RequestCode
+	} else {
+		req.requestCode = Call17RequestCode
+	}
+	return req
+}
+
 // Body fills an encoder with the call request body.
 func (req *CallRequest) Body(res SchemaResolver, enc *encoder) error {
 	args := req.args
@@ -1246,3 +1830,117 @@ func (req *ExecuteRequest) Context(ctx context.Context) *ExecuteRequest {
 	req.ctx = ctx
 	return req
 }
+
+// UrgentRequest wraps a request to send it before requests that are already
+// queued for sending. It helps to send control requests like Ping or
+// a transaction rollback under a high load.
+//
+// Requests of a stream are executed in the order of sending, so an urgent
+// stream request could be executed before previous requests of the stream.
+type UrgentRequest struct {
+	Request
+}
+
+// NewUrgentRequest returns a new UrgentRequest which wraps the request.
+func NewUrgentRequest(req Request) *UrgentRequest {
+	return &UrgentRequest{
+		Request: req,
+	}
+}
+
+// Conn returns a Connection the wrapped request belongs to or nil if it is
+// not a ConnectedRequest.
+func (req *UrgentRequest) Conn() *Connection {
+	return requestConn(req.Request)
+}
+
+func (req *UrgentRequest) urgent() bool {
+	return true
+}
+
+func (req *UrgentRequest) expectedSchemaVersion() (uint64, bool) {
+	return requestSchemaVersion(req.Request)
+}
+
+func (req *UrgentRequest) schemaSpaceIndex() (space, index interface{}) {
+	return requestSpaceIndex(req.Request)
+}
+
+// urgentRequest is implemented by UrgentRequest and wrappers which forward
+// the urgency of a wrapped request.
+type urgentRequest interface {
+	urgent() bool
+}
+
+// isUrgentRequest returns true if the request should be sent before queued
+// requests.
+func isUrgentRequest(req Request) bool {
+	ureq, ok := req.(urgentRequest)
+	return ok && ureq.urgent()
+}
+
+// SchemaVersionRequest wraps a request and adds an expected schema version
+// to the request header. Tarantool rejects the request with
+// ErrWrongSchemaVaersion error if the current schema version differs from
+// the expected one. It allows to execute requests only if the schema has not
+// been changed since the last read. The current schema version could be
+// obtained from Response.SchemaVersion.
+type SchemaVersionRequest struct {
+	Request
+	version uint64
+}
+
+// NewSchemaVersionRequest returns a new SchemaVersionRequest which wraps
+// the request with the expected schema version.
+func NewSchemaVersionRequest(req Request, version uint64) *SchemaVersionRequest {
+	return &SchemaVersionRequest{
+		Request: req,
+		version: version,
+	}
+}
+
+// SchemaVersion returns the expected schema version.
+func (req *SchemaVersionRequest) SchemaVersion() uint64 {
+	return req.version
+}
+
+// Conn returns a Connection the wrapped request belongs to or nil if it is
+// not a ConnectedRequest.
+func (req *SchemaVersionRequest) Conn() *Connection {
+	return requestConn(req.Request)
+}
+
+func (req *SchemaVersionRequest) expectedSchemaVersion() (uint64, bool) {
+	return req.version, true
+}
+
+func (req *SchemaVersionRequest) urgent() bool {
+	return isUrgentRequest(req.Request)
+}
+
+func (req *SchemaVersionRequest) schemaSpaceIndex() (space, index interface{}) {
+	return requestSpaceIndex(req.Request)
+}
+
+// versionedRequest is implemented by SchemaVersionRequest and wrappers
+// which forward the expected schema version of a wrapped request.
+type versionedRequest interface {
+	expectedSchemaVersion() (uint64, bool)
+}
+
+// requestSchemaVersion returns the expected schema version of the request
+// and true if it is set.
+func requestSchemaVersion(req Request) (uint64, bool) {
+	if vreq, ok := req.(versionedRequest); ok {
+		return vreq.expectedSchemaVersion()
+	}
+	return 0, false
+}
+
+// requestConn returns a Connection the request belongs to or nil.
+func requestConn(req Request) *Connection {
+	if connectedReq, ok := req.(ConnectedRequest); ok {
+		return connectedReq.Conn()
+	}
+	return nil
+}
@@ -178,6 +178,9 @@ func TestRequestsCodes(t *testing.T) {
 		{req: NewDeleteRequest(validSpace), code: DeleteRequestCode},
 		{req: NewCall16Request(validExpr), code: Call16RequestCode},
 		{req: NewCall17Request(validExpr), code: Call17RequestCode},
+		{req: NewCallRequest(validExpr).Format(Call16), code: Call16RequestCode},
+		{req: NewCallRequest(validExpr).Format(Call17), code: Call17RequestCode},
+		{req: NewCall17Request(validExpr).Format(Call16), code: Call16RequestCode},
 		{req: NewEvalRequest(validExpr), code: EvalRequestCode},
 		{req: NewExecuteRequest(validExpr), code: ExecuteRequestCode},
 		{req: NewPingRequest(), code: PingRequestCode},
@@ -189,6 +192,7 @@ func TestRequestsCodes(t *testing.T) {
 		{req: NewRollbackRequest(), code: RollbackRequestCode},
 		{req: NewIdRequest(validProtocolInfo), code: IdRequestCode},
 		{req: NewBroadcastRequest(validKey), code: CallRequestCode},
+		{req: NewWatchOnceRequest(validKey), code: WatchOnceRequestCode},
 	}
 
 	for _, test := range tests {
@@ -222,6 +226,7 @@ func TestRequestsAsync(t *testing.T) {
 		{req: NewRollbackRequest(), async: false},
 		{req: NewIdRequest(validProtocolInfo), async: false},
 		{req: NewBroadcastRequest(validKey), async: false},
+		{req: NewWatchOnceRequest(validKey), async: false},
 	}
 
 	for _, test := range tests {
@@ -409,6 +414,177 @@ func TestSelectRequestSetters(t *testing.T) {
 	assertBodyEqual(t, refBufAfterKey.Bytes(), reqAfterKey)
 }
 
+func TestSelectRequestUnlimited(t *testing.T) {
+	var refBuf bytes.Buffer
+
+	refEnc := NewEncoder(&refBuf)
+	err := RefImplSelectBody(refEnc, validSpace, defaultIndex, 0, 0xFFFFFFFF,
+		IterAll, []interface{}{}, nil, false)
+	if err != nil {
+		t.Errorf("An unexpected RefImplSelectBody() error %q", err.Error())
+		return
+	}
+
+	req := NewSelectRequest(validSpace).
+		Limit(0).
+		Unlimited()
+	assertBodyEqual(t, refBuf.Bytes(), req)
+}
+
+func TestSelectRequestCompositeKey(t *testing.T) {
+	index := &Index{
+		Id:      0,
+		SpaceId: 1,
+		Name:    "primary",
+		Fields:  []*IndexField{{Id: 0}, {Id: 1}},
+	}
+	space := &Space{
+		Id:          1,
+		Name:        "test",
+		Indexes:     map[string]*Index{index.Name: index},
+		IndexesById: map[uint32]*Index{index.Id: index},
+	}
+	schema := &Schema{
+		Spaces:     map[string]*Space{space.Name: space},
+		SpacesById: map[uint32]*Space{space.Id: space},
+	}
+
+	var refBuf bytes.Buffer
+	refEnc := NewEncoder(&refBuf)
+	err := RefImplSelectBody(refEnc, 1, 0, 0, 0xFFFFFFFF, IterEq,
+		[]interface{}{uint(1), "a"}, nil, false)
+	assert.Nil(t, err)
+
+	req := NewSelectRequest("test").
+		Index("primary").
+		Key(Key(uint(1), "a"))
+	body, err := test_helpers.ExtractRequestBody(req, schema, NewEncoder)
+	assert.Nil(t, err)
+	assert.Equal(t, refBuf.Bytes(), body)
+
+	var buf bytes.Buffer
+	req = NewSelectRequest("test").
+		Key(Key(uint(1)))
+	err = req.Body(schema, NewEncoder(&buf))
+	assert.EqualError(t, err,
+		"invalid key: 1 parts, index primary of space test has 2 parts")
+
+	// The key is not validated without the schema.
+	req = NewSelectRequest(1).
+		Key(Key(uint(1)))
+	_, err = test_helpers.ExtractRequestBody(req, &resolver, NewEncoder)
+	assert.Nil(t, err)
+}
+
+func TestSelectRequestByField(t *testing.T) {
+	primary := &Index{
+		Id:      0,
+		SpaceId: 1,
+		Name:    "primary",
+		Unique:  true,
+		Fields:  []*IndexField{{Id: 0}},
+	}
+	byName := &Index{
+		Id:      1,
+		SpaceId: 1,
+		Name:    "name",
+		Fields:  []*IndexField{{Id: 1}, {Id: 0}},
+	}
+	byEmail := &Index{
+		Id:      2,
+		SpaceId: 1,
+		Name:    "email",
+		Fields:  []*IndexField{{Id: 2}},
+	}
+	byEmailUnique := &Index{
+		Id:      3,
+		SpaceId: 1,
+		Name:    "email_unique",
+		Unique:  true,
+		Fields:  []*IndexField{{Id: 2}},
+	}
+	fields := []*Field{{Id: 0, Name: "id"}, {Id: 1, Name: "name"},
+		{Id: 2, Name: "email"}, {Id: 3, Name: "age"}}
+	space := &Space{
+		Id:          1,
+		Name:        "test",
+		Fields:      map[string]*Field{},
+		FieldsById:  map[uint32]*Field{},
+		Indexes:     map[string]*Index{},
+		IndexesById: map[uint32]*Index{},
+	}
+	for _, field := range fields {
+		space.Fields[field.Name] = field
+		space.FieldsById[field.Id] = field
+	}
+	for _, index := range []*Index{primary, byName, byEmail, byEmailUnique} {
+		space.Indexes[index.Name] = index
+		space.IndexesById[index.Id] = index
+	}
+	schema := &Schema{
+		Spaces:     map[string]*Space{space.Name: space},
+		SpacesById: map[uint32]*Space{space.Id: space},
+	}
+
+	cases := []struct {
+		field string
+		index uint32
+	}{
+		{"id", 0},
+		{"name", 1},
+		{"email", 3},
+	}
+	for _, tc := range cases {
+		var refBuf bytes.Buffer
+		refEnc := NewEncoder(&refBuf)
+		err := RefImplSelectBody(refEnc, 1, tc.index, 0, 0xFFFFFFFF, IterEq,
+			[]interface{}{"value"}, nil, false)
+		assert.Nil(t, err)
+
+		req := NewSelectRequest("test").ByField(tc.field, "value")
+		body, err := test_helpers.ExtractRequestBody(req, schema, NewEncoder)
+		assert.Nil(t, err, tc.field)
+		assert.Equal(t, refBuf.Bytes(), body, tc.field)
+	}
+
+	var buf bytes.Buffer
+	req := NewSelectRequest("test").ByField("age", 1)
+	err := req.Body(schema, NewEncoder(&buf))
+	assert.EqualError(t, err, "space test has not index by field age")
+
+	req = NewSelectRequest("test").ByField("unknown", 1)
+	err = req.Body(schema, NewEncoder(&buf))
+	assert.EqualError(t, err, "space test has not field with name unknown")
+
+	req = NewSelectRequest(1).ByField("id", 1)
+	err = req.Body(&resolver, NewEncoder(&buf))
+	assert.EqualError(t, err,
+		"unable to find an index by field id: the schema is not loaded")
+}
+
+func TestSelectRequestPage(t *testing.T) {
+	var refBuf bytes.Buffer
+	refEnc := NewEncoder(&refBuf)
+	err := RefImplSelectBody(refEnc, validSpace, validIndex, 20, 10, IterLe,
+		[]interface{}{uint(100)}, nil, false)
+	assert.Nil(t, err)
+
+	req := NewSelectRequest(validSpace).
+		Page(validIndex, IterLe, []interface{}{uint(100)}, 10, 20)
+	assertBodyEqual(t, refBuf.Bytes(), req)
+
+	var buf bytes.Buffer
+	req = NewSelectRequest(validSpace).
+		Page(validIndex, IterGe, []interface{}{uint(100)}, 0, 20)
+	err = req.Body(&resolver, NewEncoder(&buf))
+	assert.EqualError(t, err, "page size must be greater than zero")
+
+	req = NewSelectRequest(validSpace).
+		Page(validIndex, IterBitsAllSet, []interface{}{uint(100)}, 10, 20)
+	err = req.Body(&resolver, NewEncoder(&buf))
+	assert.EqualError(t, err, "iterator 7 does not support pagination")
+}
+
 func TestInsertRequestDefaultValues(t *testing.T) {
 	var refBuf bytes.Buffer
 
@@ -439,6 +615,41 @@ func TestInsertRequestSetters(t *testing.T) {
 	assertBodyEqual(t, refBuf.Bytes(), req)
 }
 
+type tupleEncoder struct {
+	id    uint
+	calls int
+}
+
+func (tuple *tupleEncoder) EncodeTuple(e *encoder) error {
+	tuple.calls++
+	if err := e.EncodeArrayLen(1); err != nil {
+		return err
+	}
+	return encodeUint(e, uint64(tuple.id))
+}
+
+func TestRequestsTupleEncoder(t *testing.T) {
+	refTuple := []interface{}{uint(24)}
+	refOps, reqOps := getTestOps()
+
+	var refInsert, refReplace, refUpsert bytes.Buffer
+	err := RefImplInsertBody(NewEncoder(&refInsert), validSpace, refTuple)
+	assert.Nil(t, err)
+	err = RefImplReplaceBody(NewEncoder(&refReplace), validSpace, refTuple)
+	assert.Nil(t, err)
+	err = RefImplUpsertBody(NewEncoder(&refUpsert), validSpace, refTuple, refOps)
+	assert.Nil(t, err)
+
+	tuple := &tupleEncoder{id: 24}
+	assertBodyEqual(t, refInsert.Bytes(),
+		NewInsertRequest(validSpace).Tuple(tuple))
+	assertBodyEqual(t, refReplace.Bytes(),
+		NewReplaceRequest(validSpace).Tuple(tuple))
+	assertBodyEqual(t, refUpsert.Bytes(),
+		NewUpsertRequest(validSpace).Tuple(tuple).Operations(reqOps))
+	assert.Equal(t, 3, tuple.calls)
+}
+
 func TestReplaceRequestDefaultValues(t *testing.T) {
 	var refBuf bytes.Buffer
 
@@ -565,6 +776,41 @@ func TestUpsertRequestSetters(t *testing.T) {
 	assertBodyEqual(t, refBuf.Bytes(), req)
 }
 
+func TestUpsertRequestIncrement(t *testing.T) {
+	cases := []struct {
+		tuple    []interface{}
+		field    int
+		expected []interface{}
+	}{
+		{[]interface{}{uint(64), uint(0)}, 1, []interface{}{uint(64), 5}},
+		{[]interface{}{uint(64)}, 2, []interface{}{uint(64), nil, 5}},
+	}
+	for _, tc := range cases {
+		var refBuf bytes.Buffer
+
+		refOps := []interface{}{Op{"+", tc.field, 5}}
+		refEnc := NewEncoder(&refBuf)
+		err := RefImplUpsertBody(refEnc, validSpace, tc.expected, refOps)
+		if err != nil {
+			t.Errorf("An unexpected RefImplUpsertBody() error: %q", err.Error())
+			return
+		}
+
+		req := NewUpsertRequest(validSpace).Increment(tc.field, 5, tc.tuple)
+		assertBodyEqual(t, refBuf.Bytes(), req)
+	}
+}
+
+func TestUpsertRequestIncrementNegativeField(t *testing.T) {
+	var buf bytes.Buffer
+
+	req := NewUpsertRequest(validSpace).Increment(-1, 5, []interface{}{uint(64)})
+	err := req.Body(&resolver, NewEncoder(&buf))
+	if err == nil {
+		t.Errorf("An error expected for a negative field")
+	}
+}
+
 func TestCallRequestsDefaultValues(t *testing.T) {
 	var refBuf bytes.Buffer
 
@@ -730,7 +976,7 @@ func TestBeginRequestDefaultValues(t *testing.T) {
 	var refBuf bytes.Buffer
 
 	refEnc := NewEncoder(&refBuf)
-	err := RefImplBeginBody(refEnc, defaultIsolationLevel, defaultTimeout)
+	err := RefImplBeginBody(refEnc, defaultIsolationLevel, defaultTimeout, false)
 	if err != nil {
 		t.Errorf("An unexpected RefImplBeginBody() error: %q", err.Error())
 		return
@@ -744,13 +990,14 @@ func TestBeginRequestSetters(t *testing.T) {
 	var refBuf bytes.Buffer
 
 	refEnc := NewEncoder(&refBuf)
-	err := RefImplBeginBody(refEnc, ReadConfirmedLevel, validTimeout)
+	err := RefImplBeginBody(refEnc, ReadConfirmedLevel, validTimeout, true)
 	if err != nil {
 		t.Errorf("An unexpected RefImplBeginBody() error: %q", err.Error())
 		return
 	}
 
-	req := NewBeginRequest().TxnIsolation(ReadConfirmedLevel).Timeout(validTimeout)
+	req := NewBeginRequest().TxnIsolation(ReadConfirmedLevel).Timeout(validTimeout).
+		IsSync(true)
 	assertBodyEqual(t, refBuf.Bytes(), req)
 }
 
@@ -758,7 +1005,7 @@ func TestCommitRequestDefaultValues(t *testing.T) {
 	var refBuf bytes.Buffer
 
 	refEnc := NewEncoder(&refBuf)
-	err := RefImplCommitBody(refEnc)
+	err := RefImplCommitBody(refEnc, false)
 	if err != nil {
 		t.Errorf("An unexpected RefImplCommitBody() error: %q", err.Error())
 		return
@@ -768,6 +1015,20 @@ func TestCommitRequestDefaultValues(t *testing.T) {
 	assertBodyEqual(t, refBuf.Bytes(), req)
 }
 
+func TestCommitRequestSetters(t *testing.T) {
+	var refBuf bytes.Buffer
+
+	refEnc := NewEncoder(&refBuf)
+	err := RefImplCommitBody(refEnc, true)
+	if err != nil {
+		t.Errorf("An unexpected RefImplCommitBody() error: %q", err.Error())
+		return
+	}
+
+	req := NewCommitRequest().IsSync(true)
+	assertBodyEqual(t, refBuf.Bytes(), req)
+}
+
 func TestRollbackRequestDefaultValues(t *testing.T) {
 	var refBuf bytes.Buffer
 
@@ -812,3 +1073,191 @@ func TestBroadcastRequestSetters(t *testing.T) {
 	req := NewBroadcastRequest(validKey).Value(value)
 	assertBodyEqual(t, refBuf.Bytes(), req)
 }
+
+func TestSchemaVersionRequest(t *testing.T) {
+	var refBuf bytes.Buffer
+
+	refEnc := NewEncoder(&refBuf)
+	err := RefImplPingBody(refEnc)
+	if err != nil {
+		t.Errorf("An unexpected RefImplPingBody() error: %q", err.Error())
+		return
+	}
+
+	req := NewSchemaVersionRequest(NewPingRequest(), 42)
+	if code := req.Code(); code != PingRequestCode {
+		t.Errorf("An invalid request code 0x%x, expected 0x%x", code, PingRequestCode)
+	}
+	if version := req.SchemaVersion(); version != 42 {
+		t.Errorf("An invalid schema version %d, expected 42", version)
+	}
+	if conn := req.Conn(); conn != nil {
+		t.Errorf("An unexpected connection %v, expected nil", conn)
+	}
+	assertBodyEqual(t, refBuf.Bytes(), req)
+}
+
+func TestEncodeRequest(t *testing.T) {
+	var refBuf bytes.Buffer
+
+	refEnc := NewEncoder(&refBuf)
+	err := RefImplSelectBody(refEnc, validSpace, validIndex, 2, 3, IterEq,
+		[]interface{}{uint(1)}, nil, false)
+	if err != nil {
+		t.Fatalf("An unexpected RefImplSelectBody() error %q", err.Error())
+	}
+
+	req := NewSelectRequest(validSpace).
+		Index(validIndex).
+		Offset(2).
+		Limit(3).
+		Iterator(IterEq).
+		Key([]interface{}{uint(1)})
+	body, err := EncodeRequest(req, &resolver)
+	if err != nil {
+		t.Fatalf("An unexpected EncodeRequest() error %q", err.Error())
+	}
+	assert.Equal(t, refBuf.Bytes(), body)
+}
+
+func TestEncodeRequest_error(t *testing.T) {
+	req := NewSelectRequest(invalidSpace)
+	_, err := EncodeRequest(req, &resolver)
+	assert.NotNil(t, err)
+}
+
+func TestMapSchemaResolver(t *testing.T) {
+	var refBuf bytes.Buffer
+
+	refEnc := NewEncoder(&refBuf)
+	err := RefImplSelectBody(refEnc, validSpace, validIndex, 0, 0xFFFFFFFF,
+		IterAll, []interface{}{}, nil, false)
+	if err != nil {
+		t.Fatalf("An unexpected RefImplSelectBody() error %q", err.Error())
+	}
+
+	res := test_helpers.NewMapSchemaResolver(
+		map[string]uint32{"space": validSpace},
+		map[string]uint32{"index": validIndex})
+	req := NewSelectRequest("space").Index("index")
+	body, err := EncodeRequest(req, res)
+	if err != nil {
+		t.Fatalf("An unexpected EncodeRequest() error %q", err.Error())
+	}
+	assert.Equal(t, refBuf.Bytes(), body)
+
+	_, err = EncodeRequest(NewSelectRequest("unknown"), res)
+	assert.NotNil(t, err)
+	_, err = EncodeRequest(NewSelectRequest("space").Index("unknown"), res)
+	assert.NotNil(t, err)
+}
+
+func TestUrgentRequest(t *testing.T) {
+	var refBuf bytes.Buffer
+
+	refEnc := NewEncoder(&refBuf)
+	err := RefImplPingBody(refEnc)
+	if err != nil {
+		t.Errorf("An unexpected RefImplPingBody() error: %q", err.Error())
+		return
+	}
+
+	req := NewUrgentRequest(NewPingRequest())
+	if code := req.Code(); code != PingRequestCode {
+		t.Errorf("An invalid request code 0x%x, expected 0x%x", code, PingRequestCode)
+	}
+	if async := req.Async(); async {
+		t.Errorf("An invalid async %t, expected false", async)
+	}
+	if !IsUrgentRequest(req) {
+		t.Errorf("The request is not urgent")
+	}
+	assertBodyEqual(t, refBuf.Bytes(), req)
+}
+
+func TestUrgentRequest_nested(t *testing.T) {
+	if IsUrgentRequest(NewSchemaVersionRequest(NewPingRequest(), 42)) {
+		t.Errorf("A schema version request is urgent")
+	}
+
+	reqs := []Request{
+		NewUrgentRequest(NewSchemaVersionRequest(NewPingRequest(), 42)),
+		NewSchemaVersionRequest(NewUrgentRequest(NewPingRequest()), 42),
+	}
+	for _, req := range reqs {
+		if !IsUrgentRequest(req) {
+			t.Errorf("The request %T is not urgent", req)
+		}
+	}
+}
+
+type NamesSchemeResolver struct {
+}
+
+func (*NamesSchemeResolver) ResolveSpaceIndex(s, i interface{}) (spaceNo, indexNo uint32, err error) {
+	if s, ok := s.(uint32); ok {
+		spaceNo = s
+	}
+	if i, ok := i.(int); ok {
+		indexNo = uint32(i)
+	}
+	return spaceNo, indexNo, nil
+}
+
+func (*NamesSchemeResolver) NamesUseSupported() bool {
+	return true
+}
+
+func TestRequestsSpaceAndIndexNames(t *testing.T) {
+	key := []interface{}{uint(1)}
+
+	var refBuf bytes.Buffer
+	refEnc := NewEncoder(&refBuf)
+	refEnc.EncodeMapLen(3)
+	refEnc.EncodeUint(KeySpaceName)
+	refEnc.EncodeString("space_name")
+	refEnc.EncodeUint(KeyIndexName)
+	refEnc.EncodeString("index_name")
+	refEnc.EncodeUint(KeyKey)
+	refEnc.Encode(key)
+
+	req := NewDeleteRequest("space_name").Index("index_name").Key(key)
+	reqBody, err := test_helpers.ExtractRequestBody(req, &NamesSchemeResolver{}, NewEncoder)
+	if err != nil {
+		t.Fatalf("An unexpected Request.Body() error: %q", err.Error())
+	}
+	if !bytes.Equal(reqBody, refBuf.Bytes()) {
+		t.Errorf("Encoded request %v != reference %v", reqBody, refBuf.Bytes())
+	}
+
+	refBuf.Reset()
+	refEnc = NewEncoder(&refBuf)
+	refEnc.EncodeMapLen(3)
+	refEnc.EncodeUint(KeySpaceName)
+	refEnc.EncodeString("space_name")
+	refEnc.EncodeUint(KeyIndexNo)
+	refEnc.EncodeUint(validIndex)
+	refEnc.EncodeUint(KeyKey)
+	refEnc.Encode(key)
+
+	req = NewDeleteRequest("space_name").Index(validIndex).Key(key)
+	reqBody, err = test_helpers.ExtractRequestBody(req, &NamesSchemeResolver{}, NewEncoder)
+	if err != nil {
+		t.Fatalf("An unexpected Request.Body() error: %q", err.Error())
+	}
+	if !bytes.Equal(reqBody, refBuf.Bytes()) {
+		t.Errorf("Encoded request %v != reference %v", reqBody, refBuf.Bytes())
+	}
+}
+
+func TestWatchOnceRequestDefaultValues(t *testing.T) {
+	var refBuf bytes.Buffer
+
+	refEnc := NewEncoder(&refBuf)
+	refEnc.EncodeMapLen(1)
+	refEnc.EncodeUint(KeyEvent)
+	refEnc.EncodeString(validKey)
+
+	req := NewWatchOnceRequest(validKey)
+	assertBodyEqual(t, refBuf.Bytes(), req)
+}
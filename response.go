@@ -1,12 +1,20 @@
 package tarantool
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"math"
+	"reflect"
 )
 
 type Response struct {
 	RequestId uint32
 	Code      uint32
+	// SchemaVersion is a schema version of the Tarantool instance at the
+	// moment of the request execution.
+	SchemaVersion uint64
 	// Error contains an error message.
 	Error string
 	// Data contains deserialized data for untyped requests.
@@ -16,6 +24,25 @@ type Response struct {
 	MetaData []ColumnMetaData
 	SQLInfo  SQLInfo
 	buf      smallBuf
+	// typ is the IPROTO_REQUEST_TYPE value of the header as is, Code is
+	// modified on decoding of an error response.
+	typ uint32
+}
+
+// ResponseHeader contains fields of an IPROTO response header.
+type ResponseHeader struct {
+	// RequestId is a sync of the request the response is for.
+	RequestId uint32
+	// Type is the IPROTO_REQUEST_TYPE value of the response: OkCode,
+	// PushCode, EventCode or an error code with ErrorCodeBit set.
+	Type uint32
+	// ErrorCode is a Tarantool error code if the response is an error,
+	// otherwise it is zero.
+	ErrorCode uint32
+	// SchemaVersion is a schema version of the Tarantool instance at the
+	// moment of the request execution. It could be compared with the schema
+	// version of other responses to detect schema changes.
+	SchemaVersion uint64
 }
 
 type ColumnMetaData struct {
@@ -136,6 +163,11 @@ func (resp *Response) decodeHeader(d *decoder) (err error) {
 				return
 			}
 			resp.Code = uint32(rcode)
+			resp.typ = resp.Code
+		case KeySchemaVersion:
+			if resp.SchemaVersion, err = d.DecodeUint64(); err != nil {
+				return
+			}
 		default:
 			if err = d.Skip(); err != nil {
 				return
@@ -288,9 +320,15 @@ func (resp *Response) decodeBodyTyped(res interface{}) (err error) {
 			}
 			switch cd {
 			case KeyData:
-				if err = d.Decode(res); err != nil {
+				var decoded bool
+				if decoded, err = decodeTuples(d, res); err != nil {
 					return err
 				}
+				if !decoded {
+					if err = d.Decode(res); err != nil {
+						return err
+					}
+				}
 			case KeyError:
 				if errorExtendedInfo, err = decodeBoxError(d); err != nil {
 					return err
@@ -325,6 +363,19 @@ func (resp *Response) decodeBodyTyped(res interface{}) (err error) {
 	return
 }
 
+// Header returns fields of the response header.
+func (resp *Response) Header() ResponseHeader {
+	header := ResponseHeader{
+		RequestId:     resp.RequestId,
+		Type:          resp.typ,
+		SchemaVersion: resp.SchemaVersion,
+	}
+	if resp.typ&ErrorCodeBit != 0 {
+		header.ErrorCode = resp.typ &^ ErrorCodeBit
+	}
+	return header
+}
+
 // String implements Stringer interface.
 func (resp *Response) String() (str string) {
 	if resp.Code == OkCode {
@@ -347,3 +398,167 @@ func (resp *Response) Tuples() (res [][]interface{}) {
 	}
 	return res
 }
+
+// TupleFields is a tuple with typed field accessors. An accessor returns an
+// error if the field does not exist or has an unexpected type.
+type TupleFields []interface{}
+
+// Tuple returns the i-th tuple of the response data.
+func (resp *Response) Tuple(i int) (TupleFields, error) {
+	if i < 0 || i >= len(resp.Data) {
+		return nil, fmt.Errorf("tuple %d is out of range [0, %d)", i, len(resp.Data))
+	}
+	tuple, ok := resp.Data[i].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("tuple %d has unexpected type %T", i, resp.Data[i])
+	}
+	return TupleFields(tuple), nil
+}
+
+// FieldReader returns a reader of a binary or a string field of the tuple in
+// the response data. The reader reads the field bytes from the buffered
+// response packet. Note that the response body is decoded anyway by
+// Future.Get(), Future.GetTyped() and others, so it does not save memory
+// for the decoded data.
+func (resp *Response) FieldReader(tuple, field int) (io.Reader, error) {
+	offset := resp.buf.Offset()
+	defer resp.buf.Seek(offset)
+
+	d := newDecoder(&resp.buf)
+	l, err := d.DecodeMapLen()
+	if err != nil {
+		return nil, err
+	}
+	for ; l > 0; l-- {
+		var cd int
+		if cd, err = resp.smallInt(d); err != nil {
+			return nil, err
+		}
+		if cd != KeyData {
+			if err = d.Skip(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if err = skipArrayItems(d, tuple, "tuple"); err != nil {
+			return nil, err
+		}
+		if err = skipArrayItems(d, field, "field"); err != nil {
+			return nil, err
+		}
+
+		code, err := d.PeekCode()
+		if err != nil {
+			return nil, err
+		}
+		if !msgpackIsBin(code) && !msgpackIsString(code) {
+			return nil, fmt.Errorf("field %d is not a binary or a string", field)
+		}
+		size, err := d.DecodeBytesLen()
+		if err != nil {
+			return nil, err
+		}
+		start := resp.buf.Offset()
+		if size > resp.buf.Len() {
+			return nil, fmt.Errorf("field %d is truncated", field)
+		}
+		return bytes.NewReader(resp.buf.b[start : start+size]), nil
+	}
+	return nil, errors.New("response has no data")
+}
+
+// skipArrayItems decodes an array length and skips n items of the array.
+func skipArrayItems(d *decoder, n int, name string) error {
+	l, err := d.DecodeArrayLen()
+	if err != nil {
+		return err
+	}
+	if n < 0 || n >= l {
+		return fmt.Errorf("%s %d is out of range [0, %d)", name, n, l)
+	}
+	for ; n > 0; n-- {
+		if err = d.Skip(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (tuple TupleFields) field(field int) (reflect.Value, error) {
+	if field < 0 || field >= len(tuple) {
+		return reflect.Value{}, fmt.Errorf("field %d is out of range [0, %d)",
+			field, len(tuple))
+	}
+	return reflect.ValueOf(tuple[field]), nil
+}
+
+// Uint returns the field as an unsigned integer.
+func (tuple TupleFields) Uint(field int) (uint64, error) {
+	val, err := tuple.field(field)
+	if err != nil {
+		return 0, err
+	}
+	switch val.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return val.Uint(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if val.Int() >= 0 {
+			return uint64(val.Int()), nil
+		}
+	}
+	return 0, fmt.Errorf("field %d is not an unsigned integer: %v", field, tuple[field])
+}
+
+// Int returns the field as a signed integer.
+func (tuple TupleFields) Int(field int) (int64, error) {
+	val, err := tuple.field(field)
+	if err != nil {
+		return 0, err
+	}
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return val.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if val.Uint() <= math.MaxInt64 {
+			return int64(val.Uint()), nil
+		}
+	}
+	return 0, fmt.Errorf("field %d is not a signed integer: %v", field, tuple[field])
+}
+
+// Str returns the field as a string.
+func (tuple TupleFields) Str(field int) (string, error) {
+	if _, err := tuple.field(field); err != nil {
+		return "", err
+	}
+	if str, ok := tuple[field].(string); ok {
+		return str, nil
+	}
+	return "", fmt.Errorf("field %d is not a string: %v", field, tuple[field])
+}
+
+// Bool returns the field as a boolean.
+func (tuple TupleFields) Bool(field int) (bool, error) {
+	if _, err := tuple.field(field); err != nil {
+		return false, err
+	}
+	if b, ok := tuple[field].(bool); ok {
+		return b, nil
+	}
+	return false, fmt.Errorf("field %d is not a boolean: %v", field, tuple[field])
+}
+
+// Float returns the field as a floating point number.
+func (tuple TupleFields) Float(field int) (float64, error) {
+	val, err := tuple.field(field)
+	if err != nil {
+		return 0, err
+	}
+	switch val.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return val.Float(), nil
+	}
+	return 0, fmt.Errorf("field %d is not a floating point number: %v",
+		field, tuple[field])
+}
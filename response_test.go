@@ -0,0 +1,53 @@
+package tarantool_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/tarantool/go-tarantool"
+)
+
+func TestResponse_Tuple(t *testing.T) {
+	resp := &Response{
+		Data: []interface{}{
+			[]interface{}{uint64(1), int8(-2), "str", true, float64(1.5)},
+			"not a tuple",
+		},
+	}
+
+	tuple, err := resp.Tuple(0)
+	require.Nil(t, err)
+
+	u, err := tuple.Uint(0)
+	require.Nil(t, err)
+	require.Equal(t, uint64(1), u)
+
+	i, err := tuple.Int(1)
+	require.Nil(t, err)
+	require.Equal(t, int64(-2), i)
+
+	str, err := tuple.Str(2)
+	require.Nil(t, err)
+	require.Equal(t, "str", str)
+
+	b, err := tuple.Bool(3)
+	require.Nil(t, err)
+	require.True(t, b)
+
+	f, err := tuple.Float(4)
+	require.Nil(t, err)
+	require.Equal(t, 1.5, f)
+
+	_, err = tuple.Uint(1)
+	require.NotNil(t, err)
+	_, err = tuple.Str(0)
+	require.NotNil(t, err)
+	_, err = tuple.Bool(5)
+	require.NotNil(t, err)
+
+	_, err = resp.Tuple(1)
+	require.NotNil(t, err)
+	_, err = resp.Tuple(2)
+	require.NotNil(t, err)
+}
@@ -0,0 +1,66 @@
+package tarantool
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// RetryBudget is a token bucket which limits retries made with Future.Retry
+// on a connection. It prevents retry storms during a widespread outage:
+// a retry takes one token and fails fast if there are no tokens left.
+type RetryBudget struct {
+	// Rate is a number of tokens added per second.
+	Rate float64
+	// Burst is a maximum number of tokens. The bucket is full initially.
+	// The budget is disabled if Burst is zero.
+	Burst uint
+}
+
+// retryBudget is a thread-safe state of a RetryBudget.
+type retryBudget struct {
+	mutex  sync.Mutex
+	opts   RetryBudget
+	tokens float64
+	last   time.Time
+}
+
+func newRetryBudget(opts RetryBudget) *retryBudget {
+	return &retryBudget{
+		opts:   opts,
+		tokens: float64(opts.Burst),
+		last:   time.Now(),
+	}
+}
+
+// refill adds tokens for the time since the last refill. It must be called
+// under the mutex.
+func (budget *retryBudget) refill() {
+	now := time.Now()
+	budget.tokens += now.Sub(budget.last).Seconds() * budget.opts.Rate
+	budget.tokens = math.Min(budget.tokens, float64(budget.opts.Burst))
+	budget.last = now
+}
+
+// take takes a token from the budget. It returns false if there are no
+// tokens left.
+func (budget *retryBudget) take() bool {
+	budget.mutex.Lock()
+	defer budget.mutex.Unlock()
+
+	budget.refill()
+	if budget.tokens < 1 {
+		return false
+	}
+	budget.tokens--
+	return true
+}
+
+// available returns a number of available tokens.
+func (budget *retryBudget) available() float64 {
+	budget.mutex.Lock()
+	defer budget.mutex.Unlock()
+
+	budget.refill()
+	return budget.tokens
+}
@@ -3,6 +3,7 @@ package tarantool
 import (
 	"errors"
 	"fmt"
+	"time"
 )
 
 // nolint: varcheck,deadcode
@@ -10,12 +11,16 @@ const (
 	maxSchemas             = 10000
 	spaceSpId              = 280
 	vspaceSpId             = 281
+	vspaceNameIndexId      = 2
 	indexSpId              = 288
 	vindexSpId             = 289
 	vspaceSpTypeFieldNum   = 6
 	vspaceSpFormatFieldNum = 7
 )
 
+// schemaReloadRetryDelay is a delay between retries of a schema reload.
+const schemaReloadRetryDelay = 100 * time.Millisecond
+
 // SchemaResolver is an interface for resolving schema details.
 type SchemaResolver interface {
 	// ResolveSpaceIndex returns resolved space and index numbers or an
@@ -23,6 +28,46 @@ type SchemaResolver interface {
 	ResolveSpaceIndex(s interface{}, i interface{}) (spaceNo, indexNo uint32, err error)
 }
 
+// NamesSchemaResolver is a SchemaResolver which could allow to send space and
+// index names in requests as is, without resolving them into identifiers.
+// If the space is sent by name, ResolveSpaceIndex is called with a zero space
+// number to resolve a non-string index.
+type NamesSchemaResolver interface {
+	SchemaResolver
+	// NamesUseSupported returns true if space and index names could be sent
+	// in requests.
+	NamesUseSupported() bool
+}
+
+// schemaNamesResolver is a resolver for a Tarantool instance which supports
+// space and index names in requests.
+type schemaNamesResolver struct {
+	schema *Schema
+}
+
+// ResolveSpaceIndex resolves space and index numbers with the schema.
+func (res schemaNamesResolver) ResolveSpaceIndex(s interface{},
+	i interface{}) (spaceNo, indexNo uint32, err error) {
+	return res.schema.ResolveSpaceIndex(s, i)
+}
+
+// NamesUseSupported always returns true.
+func (res schemaNamesResolver) NamesUseSupported() bool {
+	return true
+}
+
+// resolverSchema returns a schema of the resolver or nil if the resolver
+// does not have a schema.
+func resolverSchema(res SchemaResolver) *Schema {
+	switch res := res.(type) {
+	case *Schema:
+		return res
+	case schemaNamesResolver:
+		return res.schema
+	}
+	return nil
+}
+
 // Schema contains information about spaces and indexes.
 type Schema struct {
 	Version uint
@@ -329,13 +374,229 @@ func (conn *Connection) loadSchema() (err error) {
 		schema.SpacesById[index.SpaceId].Indexes[index.Name] = index
 	}
 
+	conn.schemaMutex.Lock()
+	if !conn.schemaOverridden {
+		conn.lockShards()
+		conn.Schema = schema
+		conn.unlockShards()
+	}
+	conn.schemaMutex.Unlock()
+
+	return nil
+}
+
+// schemaReloader reloads the schema after a schema change. The last loaded
+// schema is kept if a reload fails after Opts.SchemaReloadRetries retries.
+// A schema set with OverrideSchema is not replaced.
+func (conn *Connection) schemaReloader() {
+	for {
+		select {
+		case <-conn.control:
+			return
+		case <-conn.schemaReload:
+		}
+
+		for retry := uint(0); ; retry++ {
+			err := conn.loadSchema()
+			if err == nil {
+				break
+			}
+			conn.opts.Logger.Report(LogSchemaReloadFailed, conn, err)
+			if retry >= conn.opts.SchemaReloadRetries {
+				break
+			}
+			select {
+			case <-conn.control:
+				return
+			case <-time.After(schemaReloadRetryDelay):
+			}
+		}
+	}
+}
+
+// schemaRequest is a request with a space and an index which are resolved
+// with the schema.
+type schemaRequest interface {
+	schemaSpaceIndex() (space, index interface{})
+}
+
+// requestSpaceIndex returns a space and an index of the request or nils if
+// the request is not a schemaRequest.
+func requestSpaceIndex(req Request) (space, index interface{}) {
+	if sreq, ok := req.(schemaRequest); ok {
+		return sreq.schemaSpaceIndex()
+	}
+	return nil, nil
+}
+
+// loadSpaceLazy loads a space of the request with indexes into the schema
+// if the request uses a name of the space or the index which could not be
+// resolved with the current schema.
+func (conn *Connection) loadSpaceLazy(req Request) error {
+	if conn.namesUseSupported {
+		return nil
+	}
+	s, i := requestSpaceIndex(req)
+	name, spaceByName := s.(string)
+	if _, indexByName := i.(string); !spaceByName && !indexByName {
+		return nil
+	}
+
+	schema := conn.GetSchema()
+	var key interface{}
+	var keyIndex uint32
+	if spaceByName {
+		if schema != nil {
+			if _, ok := schema.Spaces[name]; ok {
+				return nil
+			}
+		}
+		key, keyIndex = name, vspaceNameIndexId
+	} else {
+		spaceNo, _, err := schema.ResolveSpaceIndex(s, nil)
+		if err != nil {
+			return err
+		}
+		if schema != nil {
+			if _, ok := schema.SpacesById[spaceNo]; ok {
+				return nil
+			}
+		}
+		key, keyIndex = spaceNo, 0
+	}
+
+	var spaces []*Space
+	err := conn.SelectTyped(vspaceSpId, keyIndex, 0, 1, IterEq,
+		[]interface{}{key}, &spaces)
+	if err != nil {
+		return err
+	}
+	if len(spaces) == 0 {
+		// The resolver reports the error.
+		return nil
+	}
+	space := spaces[0]
+
+	var indexes []*Index
+	err = conn.SelectTyped(vindexSpId, 0, 0, maxSchemas, IterEq,
+		[]interface{}{space.Id}, &indexes)
+	if err != nil {
+		return err
+	}
+	for _, index := range indexes {
+		space.IndexesById[index.Id] = index
+		space.Indexes[index.Name] = index
+	}
+
+	// The space is loaded without schemaMutex to avoid blocking of
+	// GetSchema() by the network requests. The current schema could be
+	// changed meanwhile, so the space is added to the current one.
+	conn.schemaMutex.Lock()
+	defer conn.schemaMutex.Unlock()
+
+	schema = conn.Schema
+	if schema != nil {
+		if _, ok := schema.SpacesById[space.Id]; ok {
+			return nil
+		}
+	}
+
+	// The schema is copied because it could be in use by requests.
+	newSchema := new(Schema)
+	newSchema.SpacesById = make(map[uint32]*Space)
+	newSchema.Spaces = make(map[string]*Space)
+	if schema != nil {
+		newSchema.Version = schema.Version
+		for id, space := range schema.SpacesById {
+			newSchema.SpacesById[id] = space
+		}
+		for name, space := range schema.Spaces {
+			newSchema.Spaces[name] = space
+		}
+	}
+	newSchema.SpacesById[space.Id] = space
+	newSchema.Spaces[space.Name] = space
+
 	conn.lockShards()
-	conn.Schema = schema
+	conn.Schema = newSchema
 	conn.unlockShards()
 
 	return nil
 }
 
+// Space returns a space by a name or a number.
+// Note: s can be a number, string, or an object of Space type.
+func (schema *Schema) Space(s interface{}) (*Space, error) {
+	if schema == nil {
+		return nil, fmt.Errorf("Schema is not loaded")
+	}
+	if name, ok := s.(string); ok {
+		if space, ok := schema.Spaces[name]; ok {
+			return space, nil
+		}
+		return nil, fmt.Errorf("there is no space with name %s", name)
+	}
+
+	spaceNo, _, err := schema.ResolveSpaceIndex(s, nil)
+	if err != nil {
+		return nil, err
+	}
+	if space, ok := schema.SpacesById[spaceNo]; ok {
+		return space, nil
+	}
+	return nil, fmt.Errorf("there is no space with id %d", spaceNo)
+}
+
+// Index returns an index of the space by a name or a number.
+// Note: i can be a number, string, or an object of Index type.
+func (space *Space) Index(i interface{}) (*Index, error) {
+	if name, ok := i.(string); ok {
+		if index, ok := space.Indexes[name]; ok {
+			return index, nil
+		}
+		return nil, fmt.Errorf("space %s has not index with name %s",
+			space.Name, name)
+	}
+
+	var schema *Schema
+	_, indexNo, err := schema.ResolveSpaceIndex(space.Id, i)
+	if err != nil {
+		return nil, err
+	}
+	if index, ok := space.IndexesById[indexNo]; ok {
+		return index, nil
+	}
+	return nil, fmt.Errorf("space %s has not index with id %d",
+		space.Name, indexNo)
+}
+
+// IndexByField returns an index of the space which first part is the field
+// with the name from the space format. A unique index is preferred, an index
+// with the lowest number is returned if there are several such indexes.
+func (space *Space) IndexByField(name string) (*Index, error) {
+	field, ok := space.Fields[name]
+	if !ok {
+		return nil, fmt.Errorf("space %s has not field with name %s",
+			space.Name, name)
+	}
+
+	var found *Index
+	for _, index := range space.IndexesById {
+		if len(index.Fields) == 0 || index.Fields[0].Id != field.Id {
+			continue
+		}
+		if found == nil || (index.Unique && !found.Unique) ||
+			(index.Unique == found.Unique && index.Id < found.Id) {
+			found = index
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("space %s has not index by field %s",
+			space.Name, name)
+	}
+	return found, nil
+}
+
 // ResolveSpaceIndex tries to resolve space and index numbers.
 // Note: s can be a number, string, or an object of Space type.
 // Note: i can be a number, string, or an object of Index type.
@@ -0,0 +1,59 @@
+package tarantool
+
+import "fmt"
+
+const sequenceNextExpr = `
+local name = ...
+local seq = box.sequence[name]
+if seq == nil then
+    box.error(box.error.NO_SUCH_SEQUENCE, name)
+end
+return seq:next()
+`
+
+const sequenceCurrentExpr = `
+local name = ...
+local seq = box.sequence[name]
+if seq == nil then
+    box.error(box.error.NO_SUCH_SEQUENCE, name)
+end
+return seq:current()
+`
+
+// SequenceNext generates and returns the next value of the sequence.
+//
+// Error with the Tarantool NO_SUCH_SEQUENCE code is returned if the sequence
+// does not exist.
+//
+// # See also
+//
+// * box.sequence next() https://www.tarantool.io/en/doc/latest/reference/reference_lua/box_schema_sequence/next/
+func (conn *Connection) SequenceNext(name string) (int64, error) {
+	return conn.evalSequence(sequenceNextExpr, name)
+}
+
+// SequenceCurrent returns the last value retrieved from the sequence. It
+// does not change the sequence.
+//
+// Error with the Tarantool NO_SUCH_SEQUENCE code is returned if the sequence
+// does not exist. Tarantool returns an error if the sequence is not started
+// yet.
+//
+// # See also
+//
+// * box.sequence current() https://www.tarantool.io/en/doc/latest/reference/reference_lua/box_schema_sequence/current/
+func (conn *Connection) SequenceCurrent(name string) (int64, error) {
+	return conn.evalSequence(sequenceCurrentExpr, name)
+}
+
+func (conn *Connection) evalSequence(expr string, name string) (int64, error) {
+	var res []int64
+	if err := conn.EvalTyped(expr, []interface{}{name}, &res); err != nil {
+		return 0, err
+	}
+	if len(res) != 1 {
+		return 0, fmt.Errorf("unexpected sequence '%s' result length: %d",
+			name, len(res))
+	}
+	return res[0], nil
+}
@@ -0,0 +1,146 @@
+package tarantool
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// SnakeCaseStruct is utility type for encoding a struct into a map with
+// snake_case keys and decoding it back. The keys are made from Go field
+// names: CamelCase -> camel_case, UserID -> user_id. An explicit name in
+// the "msgpack" tag is used as is, fields with the "-" tag and unexported
+// fields are skipped.
+//
+// V must be a struct or a pointer to a struct for encoding and a pointer
+// to a struct for decoding. Unknown keys are skipped on decoding.
+type SnakeCaseStruct struct {
+	V interface{}
+}
+
+// snakeCaseField is a struct field with the encoded name.
+type snakeCaseField struct {
+	name  string
+	index int
+}
+
+// snakeCaseFields is a cache of struct type -> []snakeCaseField.
+var snakeCaseFields sync.Map
+
+// EncodeMsgpack encodes the struct as a map with snake_case keys.
+func (s SnakeCaseStruct) EncodeMsgpack(enc *encoder) error {
+	val := reflect.ValueOf(s.V)
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return enc.EncodeNil()
+		}
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return fmt.Errorf("SnakeCaseStruct: unsupported type %T", s.V)
+	}
+
+	fields := getSnakeCaseFields(val.Type())
+	if err := enc.EncodeMapLen(len(fields)); err != nil {
+		return err
+	}
+	for _, field := range fields {
+		if err := enc.EncodeString(field.name); err != nil {
+			return err
+		}
+		if err := enc.EncodeValue(val.Field(field.index)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecodeMsgpack decodes a map with snake_case keys into the struct.
+func (s *SnakeCaseStruct) DecodeMsgpack(d *decoder) error {
+	val := reflect.ValueOf(s.V)
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("SnakeCaseStruct: unsupported type %T, a pointer to "+
+			"a struct expected", s.V)
+	}
+	val = val.Elem()
+
+	l, err := d.DecodeMapLen()
+	if err != nil {
+		return err
+	}
+
+	fields := getSnakeCaseFields(val.Type())
+	for i := 0; i < l; i++ {
+		name, err := d.DecodeString()
+		if err != nil {
+			return err
+		}
+
+		found := false
+		for _, field := range fields {
+			if field.name == name {
+				found = true
+				if err = d.DecodeValue(val.Field(field.index)); err != nil {
+					return err
+				}
+				break
+			}
+		}
+		if !found {
+			if err = d.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func getSnakeCaseFields(typ reflect.Type) []snakeCaseField {
+	if cached, ok := snakeCaseFields.Load(typ); ok {
+		return cached.([]snakeCaseField)
+	}
+
+	fields := make([]snakeCaseField, 0, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.PkgPath != "" {
+			// Unexported field.
+			continue
+		}
+		name := strings.Split(field.Tag.Get("msgpack"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = toSnakeCase(field.Name)
+		}
+		fields = append(fields, snakeCaseField{name: name, index: i})
+	}
+
+	snakeCaseFields.Store(typ, fields)
+	return fields
+}
+
+// toSnakeCase converts a CamelCase name into a snake_case one.
+func toSnakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				prev := runes[i-1]
+				nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+				if unicode.IsLower(prev) || unicode.IsDigit(prev) ||
+					(unicode.IsUpper(prev) && nextIsLower) {
+					b.WriteByte('_')
+				}
+			}
+			b.WriteRune(unicode.ToLower(r))
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
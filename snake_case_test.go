@@ -0,0 +1,59 @@
+package tarantool_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/tarantool/go-tarantool"
+)
+
+func TestToSnakeCase(t *testing.T) {
+	cases := map[string]string{
+		"Name":       "name",
+		"FieldName":  "field_name",
+		"ID":         "id",
+		"UserID":     "user_id",
+		"HTTPServer": "http_server",
+		"Field2Name": "field2_name",
+		"already_ok": "already_ok",
+	}
+
+	for name, expected := range cases {
+		require.Equal(t, expected, ToSnakeCase(name))
+	}
+}
+
+type snakeCaseTuple struct {
+	UserID     uint
+	FirstName  string
+	Custom     string `msgpack:"CustomName"`
+	Skipped    string `msgpack:"-"`
+	unexported string
+}
+
+func TestSnakeCaseStruct(t *testing.T) {
+	tuple := snakeCaseTuple{
+		UserID:    1,
+		FirstName: "name",
+		Custom:    "custom",
+		Skipped:   "skipped",
+	}
+
+	data, err := marshal(SnakeCaseStruct{&tuple})
+	require.Nil(t, err)
+
+	var mp map[string]interface{}
+	err = unmarshal(data, &mp)
+	require.Nil(t, err)
+	require.Equal(t, 3, len(mp))
+	require.Contains(t, mp, "user_id")
+	require.Contains(t, mp, "first_name")
+	require.Contains(t, mp, "CustomName")
+
+	var decoded snakeCaseTuple
+	err = unmarshal(data, &SnakeCaseStruct{&decoded})
+	require.Nil(t, err)
+	tuple.Skipped = ""
+	require.Equal(t, tuple, decoded)
+}
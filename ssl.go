@@ -4,9 +4,14 @@
 package tarantool
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"net"
+	"strings"
 	"time"
 
 	"github.com/tarantool/go-openssl"
@@ -22,30 +27,102 @@ func sslDialTimeout(network, address string, timeout time.Duration,
 	return openssl.DialTimeout(network, address, timeout, ctx.(*openssl.Ctx), 0)
 }
 
+// sslClient establishes an SSL connection over the connection. The
+// connection is closed on error.
+func sslClient(conn net.Conn, address string, opts SslOpts) (net.Conn, error) {
+	ctx, err := sslCreateContext(opts)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	sslConn, err := openssl.Client(conn, ctx.(*openssl.Ctx))
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if err = sslConn.SetTlsExtHostName(host); err != nil {
+		sslConn.Close()
+		return nil, err
+	}
+	if err = sslConn.Handshake(); err != nil {
+		sslConn.Close()
+		return nil, err
+	}
+	if err = sslConn.VerifyHostname(host); err != nil {
+		sslConn.Close()
+		return nil, err
+	}
+	return sslConn, nil
+}
+
 // interface{} is a hack. It helps to avoid dependency of go-openssl in build
 // of tests with the tag 'go_tarantool_ssl_disable'.
 func sslCreateContext(opts SslOpts) (ctx interface{}, err error) {
 	var sslCtx *openssl.Ctx
 
-	// Require TLSv1.2, because other protocol versions don't seem to
-	// support the GOST cipher.
-	if sslCtx, err = openssl.NewCtxWithVersion(openssl.TLSv1_2); err != nil {
+	// Require TLSv1.2 by default, because other protocol versions don't seem
+	// to support the GOST cipher.
+	minVersion := openssl.Version(opts.MinVersion)
+	if minVersion == 0 {
+		minVersion = openssl.TLS1_2_VERSION
+	}
+	maxVersion := openssl.Version(opts.MaxVersion)
+	if maxVersion == 0 {
+		maxVersion = openssl.TLS1_2_VERSION
+		if minVersion > maxVersion {
+			maxVersion = minVersion
+		}
+	}
+	if minVersion > maxVersion {
+		err = fmt.Errorf("SSL MinVersion 0x%04x is greater than MaxVersion 0x%04x",
+			minVersion, maxVersion)
+		return
+	}
+
+	version := openssl.AnyVersion
+	if minVersion == openssl.TLS1_2_VERSION && maxVersion == openssl.TLS1_2_VERSION {
+		version = openssl.TLSv1_2
+	}
+	if sslCtx, err = openssl.NewCtxWithVersion(version); err != nil {
 		return
 	}
 	ctx = sslCtx
-	sslCtx.SetMaxProtoVersion(openssl.TLS1_2_VERSION)
-	sslCtx.SetMinProtoVersion(openssl.TLS1_2_VERSION)
+	if !sslCtx.SetMaxProtoVersion(maxVersion) {
+		err = fmt.Errorf("unsupported SSL MaxVersion 0x%04x", maxVersion)
+		return
+	}
+	if !sslCtx.SetMinProtoVersion(minVersion) {
+		err = fmt.Errorf("unsupported SSL MinVersion 0x%04x", minVersion)
+		return
+	}
 
-	if opts.CertFile != "" {
-		if err = sslLoadCert(sslCtx, opts.CertFile); err != nil {
+	if opts.GetClientCertificate != nil {
+		var cert *tls.Certificate
+		if cert, err = opts.GetClientCertificate(); err != nil {
 			return
 		}
-	}
-
-	if opts.KeyFile != "" {
-		if err = sslLoadKey(sslCtx, opts.KeyFile); err != nil {
+		if err = sslUseTLSCert(sslCtx, cert); err != nil {
 			return
 		}
+	} else {
+		if opts.CertFile != "" {
+			if err = sslLoadCert(sslCtx, opts.CertFile); err != nil {
+				return
+			}
+		}
+
+		if opts.KeyFile != "" {
+			if err = sslLoadKey(sslCtx, opts.KeyFile); err != nil {
+				return
+			}
+		}
 	}
 
 	if opts.CaFile != "" {
@@ -56,22 +133,72 @@ func sslCreateContext(opts SslOpts) (ctx interface{}, err error) {
 		sslCtx.SetVerify(verifyFlags, nil)
 	}
 
-	if opts.Ciphers != "" {
+	if len(opts.CipherSuites) != 0 {
+		var ciphers string
+		if ciphers, err = sslCipherList(opts.Ciphers, opts.CipherSuites); err != nil {
+			return
+		}
+		if err = sslCtx.SetCipherList(ciphers); err != nil {
+			return
+		}
+	} else if opts.Ciphers != "" {
 		sslCtx.SetCipherList(opts.Ciphers)
 	}
 
 	return
 }
 
+// sslCipherNames maps TLS 1.2 cipher suites IDs to OpenSSL cipher names.
+var sslCipherNames = map[uint16]string{
+	0x000a: "DES-CBC3-SHA",
+	0x002f: "AES128-SHA",
+	0x0035: "AES256-SHA",
+	0x003c: "AES128-SHA256",
+	0x009c: "AES128-GCM-SHA256",
+	0x009d: "AES256-GCM-SHA384",
+	0xc009: "ECDHE-ECDSA-AES128-SHA",
+	0xc00a: "ECDHE-ECDSA-AES256-SHA",
+	0xc013: "ECDHE-RSA-AES128-SHA",
+	0xc014: "ECDHE-RSA-AES256-SHA",
+	0xc023: "ECDHE-ECDSA-AES128-SHA256",
+	0xc027: "ECDHE-RSA-AES128-SHA256",
+	0xc02b: "ECDHE-ECDSA-AES128-GCM-SHA256",
+	0xc02c: "ECDHE-ECDSA-AES256-GCM-SHA384",
+	0xc02f: "ECDHE-RSA-AES128-GCM-SHA256",
+	0xc030: "ECDHE-RSA-AES256-GCM-SHA384",
+	0xcca8: "ECDHE-RSA-CHACHA20-POLY1305",
+	0xcca9: "ECDHE-ECDSA-CHACHA20-POLY1305",
+}
+
+// sslCipherList returns a colon-separated list of OpenSSL cipher names.
+func sslCipherList(ciphers string, suites []uint16) (string, error) {
+	names := make([]string, 0, len(suites)+1)
+	if ciphers != "" {
+		names = append(names, ciphers)
+	}
+	for _, suite := range suites {
+		name, ok := sslCipherNames[suite]
+		if !ok {
+			return "", fmt.Errorf("unsupported SSL cipher suite 0x%04x", suite)
+		}
+		names = append(names, name)
+	}
+	return strings.Join(names, ":"), nil
+}
+
 func sslLoadCert(ctx *openssl.Ctx, certFile string) (err error) {
 	var certBytes []byte
 	if certBytes, err = ioutil.ReadFile(certFile); err != nil {
 		return
 	}
 
+	return sslUseCert(ctx, certBytes, certFile)
+}
+
+func sslUseCert(ctx *openssl.Ctx, certBytes []byte, source string) (err error) {
 	certs := openssl.SplitPEM(certBytes)
 	if len(certs) == 0 {
-		err = errors.New("No PEM certificate found in " + certFile)
+		err = errors.New("No PEM certificate found in " + source)
 		return
 	}
 	first, certs := certs[0], certs[1:]
@@ -101,6 +228,38 @@ func sslLoadKey(ctx *openssl.Ctx, keyFile string) (err error) {
 		return
 	}
 
+	return sslUseKey(ctx, keyBytes)
+}
+
+// sslUseTLSCert converts the crypto/tls certificate into the PEM format and
+// uses it for the context.
+func sslUseTLSCert(ctx *openssl.Ctx, cert *tls.Certificate) (err error) {
+	if cert == nil || len(cert.Certificate) == 0 {
+		return errors.New("No certificate returned by GetClientCertificate")
+	}
+
+	var certBytes []byte
+	for _, der := range cert.Certificate {
+		certBytes = append(certBytes, pem.EncodeToMemory(&pem.Block{
+			Type:  "CERTIFICATE",
+			Bytes: der,
+		})...)
+	}
+	if err = sslUseCert(ctx, certBytes, "client certificate"); err != nil {
+		return
+	}
+
+	var keyDer []byte
+	if keyDer, err = x509.MarshalPKCS8PrivateKey(cert.PrivateKey); err != nil {
+		return
+	}
+	return sslUseKey(ctx, pem.EncodeToMemory(&pem.Block{
+		Type:  "PRIVATE KEY",
+		Bytes: keyDer,
+	}))
+}
+
+func sslUseKey(ctx *openssl.Ctx, keyBytes []byte) (err error) {
 	var key openssl.PrivateKey
 	if key, err = openssl.LoadPrivateKeyFromPEM(keyBytes); err != nil {
 		return
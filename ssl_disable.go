@@ -14,6 +14,12 @@ func sslDialTimeout(network, address string, timeout time.Duration,
 	return nil, errors.New("SSL support is disabled.")
 }
 
+func sslClient(conn net.Conn, address string,
+	opts SslOpts) (net.Conn, error) {
+	conn.Close()
+	return nil, errors.New("SSL support is disabled.")
+}
+
 func sslCreateContext(opts SslOpts) (ctx interface{}, err error) {
 	return nil, errors.New("SSL support is disabled.")
 }
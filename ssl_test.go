@@ -4,6 +4,8 @@
 package tarantool_test
 
 import (
+	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -14,6 +16,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/require"
 	"github.com/tarantool/go-openssl"
 	. "github.com/tarantool/go-tarantool"
 	"github.com/tarantool/go-tarantool/test_helpers"
@@ -431,6 +434,102 @@ var tests = []test{
 			Ciphers:  "TLS_AES_128_GCM_SHA256",
 		},
 	},
+	{
+		"key_crt_ca_ciphers_server_and_client_cipher_suites",
+		true,
+		SslOpts{
+			KeyFile:  "testdata/localhost.key",
+			CertFile: "testdata/localhost.crt",
+			CaFile:   "testdata/ca.crt",
+			Ciphers:  "ECDHE-RSA-AES256-GCM-SHA384",
+		},
+		SslOpts{
+			KeyFile:      "testdata/localhost.key",
+			CertFile:     "testdata/localhost.crt",
+			CaFile:       "testdata/ca.crt",
+			MinVersion:   0x0303,
+			MaxVersion:   0x0303,
+			CipherSuites: []uint16{0xc030},
+		},
+	},
+	{
+		"non_equal_cipher_suites_client",
+		false,
+		SslOpts{
+			KeyFile:  "testdata/localhost.key",
+			CertFile: "testdata/localhost.crt",
+			CaFile:   "testdata/ca.crt",
+			Ciphers:  "ECDHE-RSA-AES256-GCM-SHA384",
+		},
+		SslOpts{
+			KeyFile:      "testdata/localhost.key",
+			CertFile:     "testdata/localhost.crt",
+			CaFile:       "testdata/ca.crt",
+			CipherSuites: []uint16{0xc02f},
+		},
+	},
+	{
+		"unknown_cipher_suite_client",
+		false,
+		SslOpts{
+			KeyFile:  "testdata/localhost.key",
+			CertFile: "testdata/localhost.crt",
+		},
+		SslOpts{
+			CipherSuites: []uint16{0x1301},
+		},
+	},
+	{
+		"min_version_greater_than_max_version_client",
+		false,
+		SslOpts{
+			KeyFile:  "testdata/localhost.key",
+			CertFile: "testdata/localhost.crt",
+		},
+		SslOpts{
+			MinVersion: 0x0304,
+			MaxVersion: 0x0303,
+		},
+	},
+	{
+		"key_crt_ca_server_and_client_certificate_callback",
+		true,
+		SslOpts{
+			KeyFile:  "testdata/localhost.key",
+			CertFile: "testdata/localhost.crt",
+			CaFile:   "testdata/ca.crt",
+		},
+		SslOpts{
+			CaFile:               "testdata/ca.crt",
+			GetClientCertificate: getClientCertificate,
+		},
+	},
+	{
+		"key_crt_ca_server_and_client_certificate_callback_error",
+		false,
+		SslOpts{
+			KeyFile:  "testdata/localhost.key",
+			CertFile: "testdata/localhost.crt",
+			CaFile:   "testdata/ca.crt",
+		},
+		SslOpts{
+			KeyFile:  "testdata/localhost.key",
+			CertFile: "testdata/localhost.crt",
+			CaFile:   "testdata/ca.crt",
+			GetClientCertificate: func() (*tls.Certificate, error) {
+				return nil, errors.New("any error")
+			},
+		},
+	},
+}
+
+func getClientCertificate() (*tls.Certificate, error) {
+	cert, err := tls.LoadX509KeyPair("testdata/localhost.crt",
+		"testdata/localhost.key")
+	if err != nil {
+		return nil, err
+	}
+	return &cert, nil
 }
 
 func isTestTntSsl() bool {
@@ -467,6 +566,49 @@ func TestSslOpts(t *testing.T) {
 	}
 }
 
+func TestSslHandshakeTimeout(t *testing.T) {
+	l, err := net.Listen("tcp", sslHost+":0")
+	require.Nil(t, err)
+	defer l.Close()
+
+	go func() {
+		// The server accepts connections but does not answer.
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	const timeout = 200 * time.Millisecond
+	dialOpts := []DialOpts{
+		{
+			Transport:   "ssl",
+			DialTimeout: timeout,
+			DialFunc: func(ctx context.Context, network,
+				address string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, network, address)
+			},
+		},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	dialOpts = append(dialOpts, DialOpts{
+		Transport: "ssl",
+		Context:   ctx,
+	})
+
+	for _, opts := range dialOpts {
+		start := time.Now()
+		_, err := TtDialer{}.Dial(l.Addr().String(), opts)
+		require.NotNil(t, err)
+		require.Less(t, time.Since(start), 10*timeout)
+	}
+}
+
 func TestOpts_PapSha256Auth(t *testing.T) {
 	isTntSsl := isTestTntSsl()
 	if !isTntSsl {
@@ -2,7 +2,9 @@ package tarantool
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 )
 
@@ -25,9 +27,17 @@ const (
 type Stream struct {
 	Id   uint64
 	Conn *Connection
+
+	// mutex protects inTx and closed.
+	mutex sync.Mutex
+	// inTx is true if a transaction is started by a BeginRequest and is not
+	// finished by a CommitRequest or a RollbackRequest.
+	inTx   bool
+	closed bool
 }
 
-func fillBegin(enc *encoder, txnIsolation TxnIsolationLevel, timeout time.Duration) error {
+func fillBegin(enc *encoder, txnIsolation TxnIsolationLevel, timeout time.Duration,
+	isSync bool) error {
 	hasTimeout := timeout > 0
 	hasIsolationLevel := txnIsolation != DefaultIsolationLevel
 	mapLen := 0
@@ -37,6 +47,9 @@ func fillBegin(enc *encoder, txnIsolation TxnIsolationLevel, timeout time.Durati
 	if hasIsolationLevel {
 		mapLen += 1
 	}
+	if isSync {
+		mapLen += 1
+	}
 
 	err := enc.EncodeMapLen(mapLen)
 	if err != nil {
@@ -67,11 +80,29 @@ func fillBegin(enc *encoder, txnIsolation TxnIsolationLevel, timeout time.Durati
 		}
 	}
 
+	if isSync {
+		err = fillIsSync(enc)
+	}
+
 	return err
 }
 
-func fillCommit(enc *encoder) error {
-	return enc.EncodeMapLen(0)
+func fillCommit(enc *encoder, isSync bool) error {
+	if !isSync {
+		return enc.EncodeMapLen(0)
+	}
+
+	if err := enc.EncodeMapLen(1); err != nil {
+		return err
+	}
+	return fillIsSync(enc)
+}
+
+func fillIsSync(enc *encoder) error {
+	if err := encodeUint(enc, KeyIsSync); err != nil {
+		return err
+	}
+	return enc.EncodeBool(true)
 }
 
 func fillRollback(enc *encoder) error {
@@ -85,6 +116,7 @@ type BeginRequest struct {
 	baseRequest
 	txnIsolation TxnIsolationLevel
 	timeout      time.Duration
+	isSync       bool
 }
 
 // NewBeginRequest returns a new BeginRequest.
@@ -108,9 +140,16 @@ func (req *BeginRequest) Timeout(timeout time.Duration) *BeginRequest {
 	return req
 }
 
+// IsSync makes the transaction synchronous: a commit waits for a quorum
+// of replicas to confirm the transaction. It requires Tarantool >= 3.1.
+func (req *BeginRequest) IsSync(isSync bool) *BeginRequest {
+	req.isSync = isSync
+	return req
+}
+
 // Body fills an encoder with the begin request body.
 func (req *BeginRequest) Body(res SchemaResolver, enc *encoder) error {
-	return fillBegin(enc, req.txnIsolation, req.timeout)
+	return fillBegin(enc, req.txnIsolation, req.timeout, req.isSync)
 }
 
 // Context sets a passed context to the request.
@@ -129,6 +168,7 @@ func (req *BeginRequest) Context(ctx context.Context) *BeginRequest {
 // Commit request can not be processed out of stream.
 type CommitRequest struct {
 	baseRequest
+	isSync bool
 }
 
 // NewCommitRequest returns a new CommitRequest.
@@ -138,9 +178,17 @@ func NewCommitRequest() *CommitRequest {
 	return req
 }
 
+// IsSync makes the transaction synchronous on commit: the commit waits for
+// a quorum of replicas to confirm the transaction. It requires
+// Tarantool >= 3.1.
+func (req *CommitRequest) IsSync(isSync bool) *CommitRequest {
+	req.isSync = isSync
+	return req
+}
+
 // Body fills an encoder with the commit request body.
 func (req *CommitRequest) Body(res SchemaResolver, enc *encoder) error {
-	return fillCommit(enc)
+	return fillCommit(enc, req.isSync)
 }
 
 // Context sets a passed context to the request.
@@ -188,13 +236,95 @@ func (req *RollbackRequest) Context(ctx context.Context) *RollbackRequest {
 //
 // An error is returned if the request was formed incorrectly, or failure to
 // create the future.
+//
+// The stream tracks transactions: a BeginRequest fails if a transaction is
+// already started, a CommitRequest or a RollbackRequest fails if there is
+// no started transaction. Only transactions managed by the requests are
+// tracked, so a transaction started by box.begin() call should be finished
+// by box.commit() or box.rollback() call too.
 func (s *Stream) Do(req Request) *Future {
-	if connectedReq, ok := req.(ConnectedRequest); ok {
-		if connectedReq.Conn() != s.Conn {
+	if reqConn := requestConn(req); reqConn != nil {
+		if reqConn != s.Conn {
 			fut := NewFuture()
 			fut.SetError(fmt.Errorf("the passed connected request doesn't belong to the current connection or connection pool"))
 			return fut
 		}
 	}
+
+	s.mutex.Lock()
+	if s.closed {
+		s.mutex.Unlock()
+		fut := NewFuture()
+		fut.SetError(errors.New("the stream is closed"))
+		return fut
+	}
+	var err error
+	switch req.(type) {
+	case *BeginRequest:
+		if s.inTx {
+			err = errors.New("the stream transaction is already started")
+		}
+		s.inTx = true
+	case *CommitRequest, *RollbackRequest:
+		if !s.inTx {
+			err = errors.New("the stream transaction is not started")
+		}
+		s.inTx = false
+	}
+	s.mutex.Unlock()
+
+	if err != nil {
+		fut := NewFuture()
+		fut.SetError(err)
+		return fut
+	}
 	return s.Conn.send(req, s.Id)
 }
+
+// InTransaction returns true if a transaction is started by a BeginRequest
+// and is not finished by a CommitRequest or a RollbackRequest. The state is
+// changed when a request is sent, so it does not take into account
+// a failed BeginRequest or a transaction finished by the server, for
+// example, by a timeout.
+func (s *Stream) InTransaction() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.inTx
+}
+
+// Close closes the stream. It rolls back a transaction started by
+// a BeginRequest if the transaction is not finished by a CommitRequest or
+// a RollbackRequest, so it is safe to defer Close after NewStream. A
+// transaction started in another way, for example, by box.begin() call, is
+// not rolled back: it lasts until the transaction timeout on the server.
+//
+// Requests could not be sent via the closed stream. It is safe to call
+// Close several times.
+func (s *Stream) Close() error {
+	s.mutex.Lock()
+	if s.closed {
+		s.mutex.Unlock()
+		return nil
+	}
+	s.closed = true
+	inTx := s.inTx
+	s.inTx = false
+	s.mutex.Unlock()
+
+	if inTx {
+		_, err := s.Conn.send(NewRollbackRequest(), s.Id).Get()
+		return err
+	}
+	return nil
+}
+
+// DeleteMany performs deletion of tuples by the keys from box space in the
+// stream. It works the same way as Connection.DeleteMany.
+//
+// The keys could be deleted atomically inside a transaction: send
+// BeginRequest before the call and CommitRequest after it or
+// RollbackRequest if an error is returned.
+func (s *Stream) DeleteMany(space, index interface{}, keys []interface{},
+	stopOnError bool) ([]*Response, error) {
+	return doMany(s.Do, keys, stopOnError, deleteManyRequest(space, index))
+}
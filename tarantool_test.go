@@ -1,17 +1,21 @@
 package tarantool_test
 
 import (
+	"bytes"
 	"context"
 	"encoding/binary"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"log"
 	"math"
+	"net"
 	"os"
 	"reflect"
 	"runtime"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -710,6 +714,9 @@ func TestTtDialer(t *testing.T) {
 	assert.Contains(conn.LocalAddr().String(), "127.0.0.1")
 	assert.Equal(server, conn.RemoteAddr().String())
 	assert.NotEqual("", conn.Greeting().Version)
+	assert.NotEqual("", conn.Greeting().Salt)
+	assert.NotEqual("", conn.Greeting().ServerVersion())
+	assert.Equal("Binary", conn.Greeting().Protocol())
 
 	// Write IPROTO_PING.
 	ping := []byte{
@@ -1105,6 +1112,20 @@ func TestClient(t *testing.T) {
 		}
 	}
 
+	// Select Typed into a slice of pointers
+	var tplPtrs []*Tuple
+	err = conn.SelectTyped(spaceNo, indexNo, 0, 1, IterEq, []interface{}{uint(10)}, &tplPtrs)
+	if err != nil {
+		t.Fatalf("Failed to SelectTyped: %s", err.Error())
+	}
+	if len(tplPtrs) != 1 {
+		t.Errorf("Result len of SelectTyped != 1")
+	} else {
+		if tplPtrs[0] == nil || tplPtrs[0].Id != 10 {
+			t.Errorf("Bad value loaded from SelectTyped")
+		}
+	}
+
 	// Get Typed
 	var singleTpl = Tuple{}
 	err = conn.GetTyped(spaceNo, indexNo, []interface{}{uint(10)}, &singleTpl)
@@ -1539,6 +1560,35 @@ func TestSQLTyped(t *testing.T) {
 	}
 }
 
+func TestSQLTypedByName(t *testing.T) {
+	test_helpers.SkipIfSQLUnsupported(t)
+
+	conn := test_helpers.ConnectWithValidation(t, server, opts)
+	defer conn.Close()
+
+	type row struct {
+		Id   uint   `db:"name0"`
+		Name string `msgpack:"name1"`
+		Skip string `db:"-"`
+	}
+
+	var rows []row
+	info, meta, err := conn.ExecuteTypedByName(selectTypedQuery, []interface{}{1}, &rows)
+	require.Nil(t, err)
+	require.Equal(t, uint64(0), info.AffectedCount)
+	require.Equal(t, 2, len(meta))
+	require.Equal(t, []row{{Id: 1, Name: "test"}}, rows)
+
+	var ptrs []*row
+	_, _, err = conn.ExecuteTypedByName(selectTypedQuery, []interface{}{1}, &ptrs)
+	require.Nil(t, err)
+	require.Equal(t, []*row{{Id: 1, Name: "test"}}, ptrs)
+
+	var wrong []int
+	_, _, err = conn.ExecuteTypedByName(selectTypedQuery, []interface{}{1}, &wrong)
+	require.NotNil(t, err)
+}
+
 func TestSQLBindings(t *testing.T) {
 	test_helpers.SkipIfSQLUnsupported(t)
 
@@ -1819,6 +1869,29 @@ func TestNewPrepared(t *testing.T) {
 	}
 }
 
+func TestConnection_ConnectTimings(t *testing.T) {
+	conn := test_helpers.ConnectWithValidation(t, server, opts)
+	defer conn.Close()
+
+	timings := conn.ConnectTimings()
+	require.Greater(t, timings.Dial, time.Duration(0))
+	require.Greater(t, timings.Handshake, time.Duration(0))
+	require.Greater(t, timings.Schema, time.Duration(0))
+	require.Equal(t, timings.Total(), conn.ConnectDuration())
+}
+
+func TestConnection_ConnectTimingsSkipSchema(t *testing.T) {
+	skipSchemaOpts := opts
+	skipSchemaOpts.SkipSchema = true
+
+	conn := test_helpers.ConnectWithValidation(t, server, skipSchemaOpts)
+	defer conn.Close()
+
+	timings := conn.ConnectTimings()
+	require.Greater(t, timings.Dial, time.Duration(0))
+	require.Equal(t, time.Duration(0), timings.Schema)
+}
+
 func TestConnection_DoWithStrangerConn(t *testing.T) {
 	expectedErr := fmt.Errorf("the passed connected request doesn't belong to the current connection or connection pool")
 
@@ -1832,6 +1905,56 @@ func TestConnection_DoWithStrangerConn(t *testing.T) {
 	if err.Error() != expectedErr.Error() {
 		t.Fatalf("Unexpected error caught")
 	}
+
+	_, err = conn1.Do(NewSchemaVersionRequest(req, 1)).Get()
+	if err == nil {
+		t.Fatalf("nil error caught for a wrapped request")
+	}
+	if err.Error() != expectedErr.Error() {
+		t.Fatalf("Unexpected error caught for a wrapped request")
+	}
+}
+
+func TestConnection_PrepareCached(t *testing.T) {
+	test_helpers.SkipIfSQLUnsupported(t)
+
+	conn := test_helpers.ConnectWithValidation(t, server, opts)
+	defer conn.Close()
+
+	stmt, err := conn.PrepareCached(selectNamedQuery2)
+	require.Nilf(t, err, "failed to prepare")
+	require.NotNil(t, stmt)
+
+	cached, err := conn.PrepareCached(selectNamedQuery2)
+	require.Nilf(t, err, "failed to prepare")
+	require.True(t, stmt == cached, "statement is not cached")
+
+	executeReq := NewExecutePreparedRequest(cached)
+	_, err = conn.Do(executeReq.Args([]interface{}{1, "test"})).Get()
+	require.Nilf(t, err, "failed to execute prepared")
+}
+
+func TestConnection_PrepareCached_reconnect(t *testing.T) {
+	test_helpers.SkipIfSQLUnsupported(t)
+
+	conn := test_helpers.ConnectWithValidation(t, server, opts)
+	defer conn.Close()
+
+	stmt, err := conn.PrepareCached(selectNamedQuery2)
+	require.Nilf(t, err, "failed to prepare")
+	require.NotNil(t, stmt)
+
+	err = conn.Reconnect()
+	require.Nil(t, err)
+
+	reprepared, err := conn.PrepareCached(selectNamedQuery2)
+	require.Nilf(t, err, "failed to prepare")
+	require.NotNil(t, reprepared)
+	require.True(t, stmt != reprepared, "statement is not prepared again")
+
+	executeReq := NewExecutePreparedRequest(reprepared)
+	_, err = conn.Do(executeReq.Args([]interface{}{1, "test"})).Get()
+	require.Nilf(t, err, "failed to execute prepared")
 }
 
 func TestNewPreparedFromResponse(t *testing.T) {
@@ -2034,6 +2157,692 @@ func TestSchema(t *testing.T) {
 	}
 }
 
+func TestSchema_Space(t *testing.T) {
+	conn := test_helpers.ConnectWithValidation(t, server, opts)
+	defer conn.Close()
+
+	for _, s := range []interface{}{"schematest", 616, uint32(616)} {
+		space, err := conn.Schema.Space(s)
+		require.Nilf(t, err, "space %v", s)
+		require.Equal(t, uint32(616), space.Id)
+		require.Equal(t, "schematest", space.Name)
+	}
+
+	_, err := conn.Schema.Space("unknown_space")
+	require.NotNil(t, err)
+	_, err = conn.Schema.Space(uint32(100500))
+	require.NotNil(t, err)
+}
+
+func TestSpace_Index(t *testing.T) {
+	conn := test_helpers.ConnectWithValidation(t, server, opts)
+	defer conn.Close()
+
+	space, err := conn.Schema.Space("schematest")
+	require.Nil(t, err)
+
+	for _, i := range []interface{}{"secondary", 3, uint32(3)} {
+		index, err := space.Index(i)
+		require.Nilf(t, err, "index %v", i)
+		require.Equal(t, uint32(3), index.Id)
+		require.Equal(t, "secondary", index.Name)
+		require.Equal(t, 2, len(index.Fields))
+	}
+
+	_, err = space.Index("unknown_index")
+	require.NotNil(t, err)
+	_, err = space.Index(uint32(100500))
+	require.NotNil(t, err)
+}
+
+func TestConnection_InsertMany(t *testing.T) {
+	conn := test_helpers.ConnectWithValidation(t, server, opts)
+	defer conn.Close()
+
+	tuples := []interface{}{
+		[]interface{}{uint(3001), "hello", "world"},
+		[]interface{}{uint(3002), "hello", "world"},
+		[]interface{}{uint(3001), "duplicate", "key"},
+		[]interface{}{uint(3003), "hello", "world"},
+	}
+	defer func() {
+		for _, key := range []uint{3001, 3002, 3003} {
+			conn.Delete(spaceNo, indexNo, []interface{}{key})
+		}
+	}()
+
+	resps, err := conn.InsertMany(spaceNo, tuples, false)
+	require.NotNil(t, err)
+	batchErr, ok := err.(BatchError)
+	require.Truef(t, ok, "unexpected error type %T", err)
+	require.Equal(t, len(tuples), len(resps))
+	require.Equal(t, len(tuples), len(batchErr.Errors))
+	for i, resp := range resps {
+		if i == 2 {
+			require.Nil(t, resp)
+			tntErr, ok := batchErr.Errors[i].(Error)
+			require.Truef(t, ok, "unexpected error type %T", batchErr.Errors[i])
+			require.Equal(t, uint32(ErrTupleFound), tntErr.Code)
+		} else {
+			require.NotNil(t, resp)
+			require.Nil(t, batchErr.Errors[i])
+		}
+	}
+
+	resps, err = conn.ReplaceMany(spaceNo, tuples, true)
+	require.Nil(t, err)
+	require.Equal(t, len(tuples), len(resps))
+
+	resps, err = conn.InsertMany(spaceNo, tuples, true)
+	require.NotNil(t, err)
+	batchErr, ok = err.(BatchError)
+	require.Truef(t, ok, "unexpected error type %T", err)
+	require.NotNil(t, batchErr.Errors[0])
+	for i := range resps {
+		require.Nil(t, resps[i])
+		if i > 0 {
+			require.Nil(t, batchErr.Errors[i])
+		}
+	}
+}
+
+func TestConnection_DeleteMany(t *testing.T) {
+	conn := test_helpers.ConnectWithValidation(t, server, opts)
+	defer conn.Close()
+
+	for _, key := range []uint{3011, 3013} {
+		_, err := conn.Replace(spaceNo, []interface{}{key, "hello", "world"})
+		require.Nil(t, err)
+	}
+	defer func() {
+		for _, key := range []uint{3011, 3013} {
+			conn.Delete(spaceNo, indexNo, []interface{}{key})
+		}
+	}()
+
+	keys := []interface{}{
+		[]interface{}{uint(3011)},
+		[]interface{}{uint(3012)},
+		[]interface{}{"invalid"},
+		[]interface{}{uint(3013)},
+	}
+	resps, err := conn.DeleteMany(spaceNo, indexNo, keys, false)
+	require.NotNil(t, err)
+	batchErr, ok := err.(BatchError)
+	require.Truef(t, ok, "unexpected error type %T", err)
+	require.Equal(t, len(keys), len(resps))
+	require.Len(t, resps[0].Data, 1)
+	require.Len(t, resps[1].Data, 0)
+	require.Nil(t, resps[2])
+	require.NotNil(t, batchErr.Errors[2])
+	require.Len(t, resps[3].Data, 1)
+
+	resps, err = conn.DeleteMany(spaceNo, indexNo, keys[:2], true)
+	require.Nil(t, err)
+	require.Len(t, resps[0].Data, 0)
+	require.Len(t, resps[1].Data, 0)
+}
+
+func TestConnection_Sequence(t *testing.T) {
+	conn := test_helpers.ConnectWithValidation(t, server, opts)
+	defer conn.Close()
+
+	next, err := conn.SequenceNext("test_seq")
+	require.Nil(t, err)
+
+	current, err := conn.SequenceCurrent("test_seq")
+	require.Nil(t, err)
+	require.Equal(t, next, current)
+
+	after, err := conn.SequenceNext("test_seq")
+	require.Nil(t, err)
+	require.Equal(t, next+1, after)
+}
+
+func TestConnection_SchemaVersionRequest(t *testing.T) {
+	conn := test_helpers.ConnectWithValidation(t, server, opts)
+	defer conn.Close()
+
+	resp, err := conn.Do(NewPingRequest()).Get()
+	require.Nil(t, err)
+	require.NotNil(t, resp)
+	require.NotEqual(t, uint64(0), resp.SchemaVersion)
+	version := resp.SchemaVersion
+
+	req := NewSelectRequest(spaceNo).Key([]interface{}{uint(1010)})
+	resp, err = conn.Do(NewSchemaVersionRequest(req, version)).Get()
+	require.Nil(t, err)
+	require.Equal(t, version, resp.SchemaVersion)
+
+	_, err = conn.Do(NewSchemaVersionRequest(req, version+1)).Get()
+	require.NotNil(t, err)
+	require.True(t, IsError(err, ErrWrongSchemaVaersion))
+}
+
+func TestResponse_FieldReader(t *testing.T) {
+	conn := test_helpers.ConnectWithValidation(t, server, opts)
+	defer conn.Close()
+
+	blob := bytes.Repeat([]byte{0, 1, 2, 3}, 1024)
+	_, err := conn.Replace(spaceNo, []interface{}{uint(1020), "blob", blob})
+	require.Nil(t, err)
+	defer conn.Delete(spaceNo, indexNo, []interface{}{uint(1020)})
+
+	resp, err := conn.Select(spaceNo, indexNo, 0, 1, IterEq,
+		[]interface{}{uint(1020)})
+	require.Nil(t, err)
+
+	reader, err := resp.FieldReader(0, 2)
+	require.Nil(t, err)
+	data, err := ioutil.ReadAll(reader)
+	require.Nil(t, err)
+	require.Equal(t, blob, data)
+
+	reader, err = resp.FieldReader(0, 1)
+	require.Nil(t, err)
+	data, err = ioutil.ReadAll(reader)
+	require.Nil(t, err)
+	require.Equal(t, []byte("blob"), data)
+
+	_, err = resp.FieldReader(0, 0)
+	require.NotNil(t, err)
+	_, err = resp.FieldReader(0, 3)
+	require.NotNil(t, err)
+	_, err = resp.FieldReader(1, 0)
+	require.NotNil(t, err)
+}
+
+func TestConnection_WriteFlushMode(t *testing.T) {
+	modes := []struct {
+		name  string
+		mode  WriteFlushMode
+		delay time.Duration
+	}{
+		{"FlushWhenIdle", FlushWhenIdle, 0},
+		{"FlushImmediate", FlushImmediate, 0},
+		{"FlushBatched", FlushBatched, 10 * time.Millisecond},
+		{"FlushBatched_zeroDelay", FlushBatched, 0},
+	}
+	for _, mode := range modes {
+		t.Run(mode.name, func(t *testing.T) {
+			connOpts := opts.Clone()
+			connOpts.WriteFlushMode = mode.mode
+			connOpts.WriteFlushDelay = mode.delay
+			conn := test_helpers.ConnectWithValidation(t, server, connOpts)
+			defer conn.Close()
+
+			futures := make([]*Future, 0, 100)
+			for i := 0; i < cap(futures); i++ {
+				futures = append(futures, conn.Do(NewPingRequest()))
+			}
+			for _, fut := range futures {
+				_, err := fut.Get()
+				require.Nil(t, err)
+			}
+
+			start := time.Now()
+			_, err := conn.Ping()
+			require.Nil(t, err)
+			require.GreaterOrEqual(t, int64(time.Since(start)), int64(mode.delay))
+		})
+	}
+}
+
+func TestConnection_PingTimed(t *testing.T) {
+	conn := test_helpers.ConnectWithValidation(t, server, opts)
+	defer conn.Close()
+
+	rtt, err := conn.PingTimed()
+	require.Nil(t, err)
+	require.Greater(t, int64(rtt), int64(0))
+
+	conn.Close()
+	_, err = conn.PingTimed()
+	require.NotNil(t, err)
+}
+
+func TestConnection_CredentialsProvider(t *testing.T) {
+	var calls int32
+	connOpts := opts.Clone()
+	connOpts.User = "invalid"
+	connOpts.Pass = "invalid"
+	connOpts.CredentialsProvider = func() (string, string, error) {
+		atomic.AddInt32(&calls, 1)
+		return opts.User, opts.Pass, nil
+	}
+
+	conn := test_helpers.ConnectWithValidation(t, server, connOpts)
+	defer conn.Close()
+
+	_, err := conn.Ping()
+	require.Nil(t, err)
+	require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestConnection_CredentialsProvider_error(t *testing.T) {
+	connOpts := opts.Clone()
+	connOpts.CredentialsProvider = func() (string, string, error) {
+		return "", "", fmt.Errorf("token expired")
+	}
+
+	conn, err := Connect(server, connOpts)
+	require.Nil(t, conn)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "token expired")
+}
+
+func TestConnection_IsReadOnly(t *testing.T) {
+	conn := test_helpers.ConnectWithValidation(t, server, opts)
+	defer conn.Close()
+
+	ro, err := conn.IsReadOnly()
+	require.Nil(t, err)
+	require.False(t, ro)
+
+	_, err = conn.Eval("box.cfg{read_only = true}", []interface{}{})
+	require.Nil(t, err)
+	defer conn.Eval("box.cfg{read_only = false}", []interface{}{})
+
+	ro, err = conn.IsReadOnly()
+	require.Nil(t, err)
+	require.True(t, ro)
+}
+
+func TestCallRequest_ArrayStruct(t *testing.T) {
+	conn := test_helpers.ConnectWithValidation(t, server, opts)
+	defer conn.Close()
+
+	args := struct {
+		A string
+	}{A: "str"}
+	req := NewCallRequest("simple_concat").Args(ArrayStruct{&args})
+	resp, err := conn.Do(req).Get()
+	require.Nil(t, err)
+	require.Equal(t, []interface{}{"strstr"}, resp.Data)
+}
+
+func TestSelectTyped_ArrayStructPositions(t *testing.T) {
+	conn := test_helpers.ConnectWithValidation(t, server, opts)
+	defer conn.Close()
+
+	key := []interface{}{uint(1022)}
+	conn.Delete(spaceNo, indexNo, key)
+	defer conn.Delete(spaceNo, indexNo, key)
+
+	_, err := conn.Insert(spaceNo, []interface{}{uint(1022), "hello", "world"})
+	require.Nil(t, err)
+
+	type tuple struct {
+		Third string `tarantool:"2"`
+		Id    uint   `tarantool:"0"`
+	}
+	var tuples []tuple
+	err = conn.SelectTyped(spaceNo, indexNo, 0, 1, IterEq, key,
+		&ArrayStruct{&tuples})
+	require.Nil(t, err)
+	require.Equal(t, []tuple{{Third: "world", Id: 1022}}, tuples)
+}
+
+func TestUpsertRequest_Increment(t *testing.T) {
+	conn := test_helpers.ConnectWithValidation(t, server, opts)
+	defer conn.Close()
+
+	key := []interface{}{uint(1021)}
+	conn.Delete(spaceNo, indexNo, key)
+	defer conn.Delete(spaceNo, indexNo, key)
+
+	for i := 0; i < 2; i++ {
+		req := NewUpsertRequest(spaceNo).
+			Increment(2, 5, []interface{}{uint(1021), "counter"})
+		_, err := conn.Do(req).Get()
+		require.Nil(t, err)
+	}
+
+	resp, err := conn.Select(spaceNo, indexNo, 0, 1, IterEq, key)
+	require.Nil(t, err)
+	tuple, err := resp.Tuple(0)
+	require.Nil(t, err)
+	counter, err := tuple.Uint(2)
+	require.Nil(t, err)
+	require.Equal(t, uint64(10), counter)
+}
+
+func TestConnection_UrgentRequest(t *testing.T) {
+	conn := test_helpers.ConnectWithValidation(t, server, opts)
+	defer conn.Close()
+
+	futures := make([]*Future, 0, 1000)
+	for i := 0; i < cap(futures); i++ {
+		req := NewSelectRequest(spaceNo).Key([]interface{}{uint(1010)})
+		futures = append(futures, conn.Do(req))
+	}
+
+	resp, err := conn.Do(NewUrgentRequest(NewPingRequest())).Get()
+	require.Nil(t, err)
+	require.NotNil(t, resp)
+	require.Equal(t, OkCode, resp.Code)
+
+	for _, fut := range futures {
+		_, err := fut.Get()
+		require.Nil(t, err)
+	}
+}
+
+func TestConnection_Use(t *testing.T) {
+	conn := test_helpers.ConnectWithValidation(t, server, opts)
+	defer conn.Close()
+
+	// Internal pings pass through the interceptors too, so only eval
+	// requests are recorded.
+	var mutex sync.Mutex
+	var calls []string
+	record := func(name string, req Request) {
+		if req.Code() == EvalRequestCode {
+			mutex.Lock()
+			calls = append(calls, name)
+			mutex.Unlock()
+		}
+	}
+	getCalls := func() []string {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return append([]string(nil), calls...)
+	}
+	conn.Use(func(req Request, next func(Request) *Future) *Future {
+		record("first", req)
+		return next(req)
+	}, func(req Request, next func(Request) *Future) *Future {
+		record("second", req)
+		return next(req)
+	})
+
+	_, err := conn.Eval("return", []interface{}{})
+	require.Nil(t, err)
+	require.Equal(t, []string{"first", "second"}, getCalls())
+
+	conn.Use(func(req Request, next func(Request) *Future) *Future {
+		if req.Code() != EvalRequestCode {
+			return next(req)
+		}
+		fut := NewFuture()
+		fut.SetError(fmt.Errorf("intercepted"))
+		return fut
+	})
+
+	_, err = conn.Eval("return", []interface{}{})
+	require.NotNil(t, err)
+	require.Equal(t, "intercepted", err.Error())
+	require.Equal(t, []string{"first", "second", "first", "second"},
+		getCalls())
+}
+
+func TestFuture_Timings(t *testing.T) {
+	conn := test_helpers.ConnectWithValidation(t, server, opts)
+	defer conn.Close()
+
+	fut := conn.Do(NewPingRequest())
+	_, err := fut.Get()
+	require.Nil(t, err)
+
+	timings := fut.Timings()
+	require.GreaterOrEqual(t, int64(timings.Queue), int64(0))
+	require.Greater(t, int64(timings.Wire), int64(0))
+}
+
+func TestFuture_TimingsDoneContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var ends int32
+	connOpts := opts.Clone()
+	connOpts.OnRequestStart = func(label string, code int32) {
+		if code == PingRequestCode {
+			// The context is done after the request is checked by
+			// the connection but before it is added to the queue.
+			cancel()
+		}
+	}
+	connOpts.OnRequestEnd = func(label string, code int32, dur time.Duration, err error) {
+		if code == PingRequestCode && dur >= 0 && err != nil {
+			atomic.AddInt32(&ends, 1)
+		}
+	}
+	conn := test_helpers.ConnectWithValidation(t, server, connOpts)
+	defer conn.Close()
+
+	fut := conn.Do(NewPingRequest().Context(ctx))
+	_, err := fut.Get()
+	require.NotNil(t, err)
+	require.Equal(t, RequestTimings{}, fut.Timings())
+	require.Equal(t, int32(1), atomic.LoadInt32(&ends))
+}
+
+func TestFuture_Retry(t *testing.T) {
+	conn := test_helpers.ConnectWithValidation(t, server, opts)
+	defer conn.Close()
+
+	req := NewReplaceRequest(spaceNo).Tuple([]interface{}{uint(1030), "hello", "world"})
+	fut := conn.Do(req)
+	_, err := fut.Get()
+	require.Nil(t, err)
+
+	conn2 := test_helpers.ConnectWithValidation(t, server, opts)
+	defer conn2.Close()
+
+	resp, err := fut.Retry(conn2).Get()
+	require.Nil(t, err)
+	require.Equal(t, 1, len(resp.Data))
+
+	_, err = conn.Delete(spaceNo, 0, []interface{}{uint(1030)})
+	require.Nil(t, err)
+}
+
+func TestFuture_RetryBudget(t *testing.T) {
+	budgetOpts := opts
+	budgetOpts.RetryBudget = RetryBudget{Rate: 0.001, Burst: 1}
+	conn := test_helpers.ConnectWithValidation(t, server, budgetOpts)
+	defer conn.Close()
+
+	require.Equal(t, 1, int(conn.RetryBudget()))
+
+	fut := conn.Do(NewPingRequest())
+	_, err := fut.Get()
+	require.Nil(t, err)
+
+	_, err = fut.Retry(conn).Get()
+	require.Nil(t, err)
+	require.Less(t, conn.RetryBudget(), float64(1))
+
+	_, err = fut.Retry(conn).Get()
+	require.NotNil(t, err)
+	require.True(t, IsError(err, ErrRetryBudgetExhausted))
+}
+
+func TestConnection_RetryBudgetDisabled(t *testing.T) {
+	conn := test_helpers.ConnectWithValidation(t, server, opts)
+	defer conn.Close()
+
+	require.True(t, math.IsInf(conn.RetryBudget(), 1))
+}
+
+func TestConnection_DialFunc(t *testing.T) {
+	var called bool
+	dialOpts := opts
+	dialOpts.DialFunc = func(ctx context.Context, network,
+		address string) (net.Conn, error) {
+		called = true
+		var dialer net.Dialer
+		return dialer.DialContext(ctx, network, address)
+	}
+	conn := test_helpers.ConnectWithValidation(t, server, dialOpts)
+	defer conn.Close()
+
+	require.True(t, called)
+	_, err := conn.Ping()
+	require.Nil(t, err)
+}
+
+func TestConnection_SpaceAndIndexNames(t *testing.T) {
+	test_helpers.SkipIfSpaceAndIndexNamesUnsupported(t)
+
+	skipSchemaOpts := opts
+	skipSchemaOpts.SkipSchema = true
+	conn := test_helpers.ConnectWithValidation(t, server, skipSchemaOpts)
+	defer conn.Close()
+
+	_, err := conn.Replace("test", []interface{}{uint(1020), "hello", "world"})
+	require.Nil(t, err)
+
+	var tuples []Tuple
+	err = conn.SelectTyped("test", "primary", 0, 1, IterEq,
+		[]interface{}{uint(1020)}, &tuples)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(tuples))
+	require.Equal(t, uint(1020), tuples[0].Id)
+
+	_, err = conn.Delete("test", "primary", []interface{}{uint(1020)})
+	require.Nil(t, err)
+}
+
+func TestConnection_LazySchema(t *testing.T) {
+	test_helpers.SkipIfSpaceAndIndexNamesSupported(t)
+
+	lazyOpts := opts.Clone()
+	lazyOpts.LazySchema = true
+	conn := test_helpers.ConnectWithValidation(t, server, lazyOpts)
+	defer conn.Close()
+
+	require.Nil(t, conn.Schema)
+
+	_, err := conn.Replace("test", []interface{}{uint(1021), "hello", "world"})
+	require.Nil(t, err)
+	defer conn.Delete(spaceNo, indexNo, []interface{}{uint(1021)})
+
+	require.NotNil(t, conn.Schema)
+	require.Len(t, conn.Schema.Spaces, 1)
+	space, err := conn.Schema.Space("test")
+	require.Nil(t, err)
+	require.Equal(t, spaceNo, space.Id)
+
+	var tuples []Tuple
+	err = conn.SelectTyped(spaceNo, "primary", 0, 1, IterEq,
+		[]interface{}{uint(1021)}, &tuples)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(tuples))
+	require.Len(t, conn.Schema.Spaces, 1)
+
+	_, err = conn.Select("not_exist_space", 0, 0, 1, IterAll, []interface{}{})
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "there is no space with name")
+}
+
+func TestConnection_LazySchemaWrappedRequest(t *testing.T) {
+	test_helpers.SkipIfSpaceAndIndexNamesSupported(t)
+
+	lazyOpts := opts.Clone()
+	lazyOpts.LazySchema = true
+
+	wrappers := map[string]func(Request) Request{
+		"UrgentRequest": func(req Request) Request {
+			return NewUrgentRequest(req)
+		},
+		"SchemaVersionRequest": func(req Request) Request {
+			// Tarantool does not check a zero schema version.
+			return NewSchemaVersionRequest(req, 0)
+		},
+	}
+	for name, wrap := range wrappers {
+		t.Run(name, func(t *testing.T) {
+			conn := test_helpers.ConnectWithValidation(t, server, lazyOpts)
+			defer conn.Close()
+
+			req := NewSelectRequest("test").Index("primary").
+				Key([]interface{}{uint(1021)})
+			_, err := conn.Do(wrap(req)).Get()
+			require.Nil(t, err)
+
+			schema := conn.GetSchema()
+			require.NotNil(t, schema)
+			space, err := schema.Space("test")
+			require.Nil(t, err)
+			require.Equal(t, spaceNo, space.Id)
+		})
+	}
+}
+
+func TestConnection_SchemaReload(t *testing.T) {
+	test_helpers.SkipIfSpaceAndIndexNamesSupported(t)
+
+	reloadOpts := opts.Clone()
+	reloadOpts.SchemaReloadRetries = 3
+	conn := test_helpers.ConnectWithValidation(t, server, reloadOpts)
+	defer conn.Close()
+
+	_, err := conn.Select("schema_reload_test", 0, 0, 1, IterAll, []interface{}{})
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "there is no space with name")
+
+	_, err = conn.Eval(`
+		local s = box.schema.space.create('schema_reload_test')
+		s:create_index('primary')
+	`, []interface{}{})
+	require.Nil(t, err)
+	defer conn.Eval("box.space.schema_reload_test:drop()", []interface{}{})
+
+	for i := 0; i < 50; i++ {
+		_, err = conn.Select("schema_reload_test", 0, 0, 1, IterAll,
+			[]interface{}{})
+		if err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.Nil(t, err)
+
+	// Spaces loaded on connect are still resolved.
+	_, err = conn.Select("test", "primary", 0, 1, IterAll, []interface{}{})
+	require.Nil(t, err)
+}
+
+func TestConnection_SchemaReloadOverridden(t *testing.T) {
+	conn := test_helpers.ConnectWithValidation(t, server, opts)
+	defer conn.Close()
+
+	schema := &Schema{
+		SpacesById: map[uint32]*Space{},
+		Spaces:     map[string]*Space{},
+	}
+	conn.OverrideSchema(schema)
+
+	_, err := conn.Eval(`
+		local s = box.schema.space.create('schema_override_test')
+		s:create_index('primary')
+	`, []interface{}{})
+	require.Nil(t, err)
+	defer conn.Eval("box.space.schema_override_test:drop()", []interface{}{})
+
+	// Give the reloader a chance to replace the schema.
+	time.Sleep(100 * time.Millisecond)
+	_, err = conn.Ping()
+	require.Nil(t, err)
+	time.Sleep(100 * time.Millisecond)
+
+	require.Same(t, schema, conn.GetSchema())
+}
+
+func TestConnection_SequenceNotExist(t *testing.T) {
+	conn := test_helpers.ConnectWithValidation(t, server, opts)
+	defer conn.Close()
+
+	_, err := conn.SequenceNext("not_exist_seq")
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "not_exist_seq")
+
+	_, err = conn.SequenceCurrent("not_exist_seq")
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "not_exist_seq")
+}
+
 func TestClientNamed(t *testing.T) {
 	var resp *Response
 	var err error
@@ -2398,6 +3207,20 @@ func TestClientRequestObjects(t *testing.T) {
 		t.Errorf("result is not {{1}} : %v", resp.Data)
 	}
 
+	// Call with a format
+	req = NewCallRequest("simple_concat").Args([]interface{}{"1"}).Format(Call16)
+	resp, err = conn.Do(req).Get()
+	require.Nilf(t, err, "failed to use Call with Call16 format")
+	require.NotNil(t, resp)
+	require.Equalf(t, []interface{}{[]interface{}{"11"}}, resp.Data,
+		"result is not {{\"11\"}}")
+
+	req = NewCallRequest("simple_concat").Args([]interface{}{"1"}).Format(Call17)
+	resp, err = conn.Do(req).Get()
+	require.Nilf(t, err, "failed to use Call with Call17 format")
+	require.NotNil(t, resp)
+	require.Equalf(t, []interface{}{"11"}, resp.Data, "result is not {\"11\"}")
+
 	// Eval
 	req = NewEvalRequest("return 5 + 6")
 	resp, err = conn.Do(req).Get()
@@ -2557,47 +3380,223 @@ func TestConnectionDoSelectRequest_fetch_pos(t *testing.T) {
 	testConnectionDoSelectRequestCheck(t, resp, err, true, 2, 1010)
 }
 
-func TestConnectDoSelectRequest_after_tuple(t *testing.T) {
-	test_helpers.SkipIfPaginationUnsupported(t)
-
+func TestConnectDoSelectRequest_after_tuple(t *testing.T) {
+	test_helpers.SkipIfPaginationUnsupported(t)
+
+	conn := test_helpers.ConnectWithValidation(t, server, opts)
+	defer conn.Close()
+
+	testConnectionDoSelectRequestPrepare(t, conn)
+
+	req := NewSelectRequest(spaceNo).
+		Index(indexNo).
+		Limit(2).
+		Iterator(IterGe).
+		FetchPos(true).
+		Key([]interface{}{uint(1010)}).
+		After([]interface{}{uint(1012)})
+	resp, err := conn.Do(req).Get()
+
+	testConnectionDoSelectRequestCheck(t, resp, err, true, 2, 1013)
+}
+
+func TestConnectionDoSelectRequest_pagination_pos(t *testing.T) {
+	test_helpers.SkipIfPaginationUnsupported(t)
+
+	conn := test_helpers.ConnectWithValidation(t, server, opts)
+	defer conn.Close()
+
+	testConnectionDoSelectRequestPrepare(t, conn)
+
+	req := NewSelectRequest(spaceNo).
+		Index(indexNo).
+		Limit(2).
+		Iterator(IterGe).
+		FetchPos(true).
+		Key([]interface{}{uint(1010)})
+	resp, err := conn.Do(req).Get()
+
+	testConnectionDoSelectRequestCheck(t, resp, err, true, 2, 1010)
+
+	resp, err = conn.Do(req.After(resp.Pos)).Get()
+
+	testConnectionDoSelectRequestCheck(t, resp, err, true, 2, 1012)
+}
+
+func TestConnection_SelectTypedPos(t *testing.T) {
+	test_helpers.SkipIfPaginationUnsupported(t)
+
+	conn := test_helpers.ConnectWithValidation(t, server, opts)
+	defer conn.Close()
+
+	testConnectionDoSelectRequestPrepare(t, conn)
+
+	var tuples []Tuple
+	pos, err := conn.SelectTypedPos(spaceNo, indexNo, 0, 2, IterGe,
+		[]interface{}{uint(1010)}, nil, &tuples)
+	require.Nil(t, err)
+	require.NotEmpty(t, pos)
+	require.Len(t, tuples, 2)
+	require.Equal(t, uint(1010), tuples[0].Id)
+
+	tuples = nil
+	pos, err = conn.SelectTypedPos(spaceNo, indexNo, 0, 2, IterGe,
+		[]interface{}{uint(1010)}, pos, &tuples)
+	require.Nil(t, err)
+	require.NotEmpty(t, pos)
+	require.Len(t, tuples, 2)
+	require.Equal(t, uint(1012), tuples[0].Id)
+}
+
+func TestSelectRequest_Page(t *testing.T) {
+	conn := test_helpers.ConnectWithValidation(t, server, opts)
+	defer conn.Close()
+
+	testConnectionDoSelectRequestPrepare(t, conn)
+
+	cases := []struct {
+		name     string
+		iterator uint32
+		key      uint
+		offset   uint32
+		ids      []uint
+	}{
+		{"ascending", IterGe, 1010, 0, []uint{1010, 1011, 1012}},
+		{"ascending next", IterGe, 1010, 3, []uint{1013, 1014, 1015}},
+		{"ascending last", IterGt, 1010, 6, []uint{1017, 1018, 1019}},
+		{"descending", IterLe, 1019, 0, []uint{1019, 1018, 1017}},
+		{"descending next", IterLe, 1019, 3, []uint{1016, 1015, 1014}},
+		{"descending strict", IterLt, 1019, 3, []uint{1015, 1014, 1013}},
+		{"empty", IterGt, 1 << 30, 0, []uint{}},
+		{"offset beyond end", IterGe, 1010, 1000, []uint{}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := NewSelectRequest(spaceNo).
+				Page(indexNo, tc.iterator, []interface{}{tc.key}, 3, tc.offset)
+
+			var tuples []Tuple
+			err := conn.Do(req).GetTyped(&tuples)
+			require.Nil(t, err)
+
+			ids := make([]uint, 0, len(tuples))
+			for _, tuple := range tuples {
+				ids = append(ids, tuple.Id)
+			}
+			require.Equal(t, tc.ids, ids)
+		})
+	}
+}
+
+func TestResponse_Header(t *testing.T) {
+	conn := test_helpers.ConnectWithValidation(t, server, opts)
+	defer conn.Close()
+
+	fut := conn.Do(NewPingRequest())
+	resp, err := fut.Get()
+	require.Nil(t, err)
+
+	header := resp.Header()
+	require.Equal(t, fut.RequestId(), header.RequestId)
+	require.Equal(t, OkCode, header.Type)
+	require.Equal(t, uint32(0), header.ErrorCode)
+	require.NotZero(t, header.SchemaVersion)
+
+	fut = conn.Do(NewSelectRequest(uint32(12345)))
+	resp, err = fut.Get()
+	require.NotNil(t, err)
+	require.NotNil(t, resp)
+
+	header = resp.Header()
+	require.Equal(t, fut.RequestId(), header.RequestId)
+	require.Equal(t, uint32(ErrorCodeBit|ErrNoSuchSpace), header.Type)
+	require.Equal(t, uint32(ErrNoSuchSpace), header.ErrorCode)
+	require.NotZero(t, header.SchemaVersion)
+}
+
+type fastTuple struct {
+	Id      uint
+	Msg     string
+	Name    string
+	decoded bool
+}
+
+func (tuple *fastTuple) DecodeTuple(d *decoder) error {
+	l, err := d.DecodeArrayLen()
+	if err != nil {
+		return err
+	}
+	if l != 3 {
+		return fmt.Errorf("array len doesn't match: %d", l)
+	}
+	id, err := d.DecodeUint64()
+	if err != nil {
+		return err
+	}
+	tuple.Id = uint(id)
+	if tuple.Msg, err = d.DecodeString(); err != nil {
+		return err
+	}
+	if tuple.Name, err = d.DecodeString(); err != nil {
+		return err
+	}
+	tuple.decoded = true
+	return nil
+}
+
+func TestConnection_SelectTypedTupleDecoder(t *testing.T) {
 	conn := test_helpers.ConnectWithValidation(t, server, opts)
 	defer conn.Close()
 
 	testConnectionDoSelectRequestPrepare(t, conn)
 
-	req := NewSelectRequest(spaceNo).
-		Index(indexNo).
-		Limit(2).
-		Iterator(IterGe).
-		FetchPos(true).
-		Key([]interface{}{uint(1010)}).
-		After([]interface{}{uint(1012)})
-	resp, err := conn.Do(req).Get()
-
-	testConnectionDoSelectRequestCheck(t, resp, err, true, 2, 1013)
+	var tuples []fastTuple
+	err := conn.SelectTyped(spaceNo, indexNo, 0, 2, IterGe,
+		[]interface{}{uint(1010)}, &tuples)
+	require.Nil(t, err)
+	require.Equal(t, []fastTuple{
+		{Id: 1010, Msg: "val 1010", Name: "bla", decoded: true},
+		{Id: 1011, Msg: "val 1011", Name: "bla", decoded: true},
+	}, tuples)
+
+	var ptrs []*fastTuple
+	err = conn.SelectTyped(spaceNo, indexNo, 0, 2, IterGe,
+		[]interface{}{uint(1012)}, &ptrs)
+	require.Nil(t, err)
+	require.Equal(t, []*fastTuple{
+		{Id: 1012, Msg: "val 1012", Name: "bla", decoded: true},
+		{Id: 1013, Msg: "val 1013", Name: "bla", decoded: true},
+	}, ptrs)
 }
 
-func TestConnectionDoSelectRequest_pagination_pos(t *testing.T) {
-	test_helpers.SkipIfPaginationUnsupported(t)
-
+func TestConnection_SelectEach(t *testing.T) {
 	conn := test_helpers.ConnectWithValidation(t, server, opts)
 	defer conn.Close()
 
 	testConnectionDoSelectRequestPrepare(t, conn)
 
-	req := NewSelectRequest(spaceNo).
-		Index(indexNo).
-		Limit(2).
-		Iterator(IterGe).
-		FetchPos(true).
-		Key([]interface{}{uint(1010)})
-	resp, err := conn.Do(req).Get()
-
-	testConnectionDoSelectRequestCheck(t, resp, err, true, 2, 1010)
-
-	resp, err = conn.Do(req.After(resp.Pos)).Get()
-
-	testConnectionDoSelectRequestCheck(t, resp, err, true, 2, 1012)
+	var ids []uint
+	err := conn.SelectEach(spaceNo, indexNo, 0, 3, IterGe,
+		[]interface{}{uint(1010)}, func(d *decoder) error {
+			var tuple Tuple
+			if err := d.Decode(&tuple); err != nil {
+				return err
+			}
+			ids = append(ids, tuple.Id)
+			return nil
+		})
+	require.Nil(t, err)
+	require.Equal(t, []uint{1010, 1011, 1012}, ids)
+
+	calls := 0
+	err = conn.SelectEach(spaceNo, indexNo, 0, 3, IterGe,
+		[]interface{}{uint(1010)}, func(d *decoder) error {
+			calls++
+			return fmt.Errorf("stop")
+		})
+	require.EqualError(t, err, "stop")
+	require.Equal(t, 1, calls)
 }
 
 func TestClientRequestObjectsWithNilContext(t *testing.T) {
@@ -2657,6 +3656,60 @@ func (req *waitCtxRequest) Async() bool {
 	return NewPingRequest().Async()
 }
 
+func TestFuture_RequestId(t *testing.T) {
+	conn := test_helpers.ConnectWithValidation(t, server, opts)
+	defer conn.Close()
+
+	fut1 := conn.Do(NewPingRequest())
+	fut2 := conn.Do(NewPingRequest())
+	require.NotEqual(t, fut1.RequestId(), fut2.RequestId())
+
+	resp, err := fut1.Get()
+	require.Nil(t, err)
+	require.Equal(t, resp.RequestId, fut1.RequestId())
+
+	require.Equal(t, uint32(0), NewFuture().RequestId())
+}
+
+func TestConnection_Call17First(t *testing.T) {
+	conn := test_helpers.ConnectWithValidation(t, server, opts)
+	defer conn.Close()
+
+	var str string
+	err := conn.Call17First("simple_concat", []interface{}{"a"}, &str)
+	require.Nil(t, err)
+	require.Equal(t, "aa", str)
+
+	err = conn.Call17First("print", []interface{}{}, &str)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "returns no values")
+}
+
+func TestConnection_EvalMulti(t *testing.T) {
+	conn := test_helpers.ConnectWithValidation(t, server, opts)
+	defer conn.Close()
+
+	var res string
+	var errMsg *string
+	err := conn.EvalMulti("return ..., nil", []interface{}{"ok"}, &res, &errMsg)
+	require.Nil(t, err)
+	require.Equal(t, "ok", res)
+	require.Nil(t, errMsg)
+
+	var num uint
+	err = conn.EvalMulti("return nil, 'failed', 3", []interface{}{},
+		nil, &errMsg, &num)
+	require.Nil(t, err)
+	require.NotNil(t, errMsg)
+	require.Equal(t, "failed", *errMsg)
+	require.Equal(t, uint(3), num)
+
+	res = "unchanged"
+	err = conn.EvalMulti("return", []interface{}{}, &res)
+	require.Nil(t, err)
+	require.Equal(t, "unchanged", res)
+}
+
 func TestClientRequestObjectsWithContext(t *testing.T) {
 	var err error
 	conn := test_helpers.ConnectWithValidation(t, server, opts)
@@ -2871,6 +3924,116 @@ func TestStream_Commit(t *testing.T) {
 	}
 }
 
+func TestStream_DeleteMany(t *testing.T) {
+	test_helpers.SkipIfStreamsUnsupported(t)
+
+	conn := test_helpers.ConnectWithValidation(t, server, opts)
+	defer conn.Close()
+
+	for _, key := range []uint{1011, 1012} {
+		_, err := conn.Replace(spaceNo, []interface{}{key, "hello", "world"})
+		require.Nil(t, err)
+	}
+	defer func() {
+		for _, key := range []uint{1011, 1012} {
+			conn.Delete(spaceNo, indexNo, []interface{}{key})
+		}
+	}()
+
+	stream, err := conn.NewStream()
+	require.Nil(t, err)
+
+	_, err = stream.Do(NewBeginRequest()).Get()
+	require.Nil(t, err)
+
+	keys := []interface{}{
+		[]interface{}{uint(1011)},
+		[]interface{}{uint(1012)},
+	}
+	resps, err := stream.DeleteMany(spaceNo, indexNo, keys, false)
+	require.Nil(t, err)
+	require.Len(t, resps, 2)
+
+	// The tuples are not deleted outside the transaction until commit.
+	resp, err := conn.Select(spaceNo, indexNo, 0, 10, IterEq,
+		[]interface{}{uint(1011)})
+	require.Nil(t, err)
+	require.Len(t, resp.Data, 1)
+
+	_, err = stream.Do(NewRollbackRequest()).Get()
+	require.Nil(t, err)
+
+	resp, err = conn.Select(spaceNo, indexNo, 0, 10, IterEq,
+		[]interface{}{uint(1012)})
+	require.Nil(t, err)
+	require.Len(t, resp.Data, 1)
+}
+
+func TestStream_Close(t *testing.T) {
+	test_helpers.SkipIfStreamsUnsupported(t)
+
+	conn := test_helpers.ConnectWithValidation(t, server, opts)
+	defer conn.Close()
+
+	stream, err := conn.NewStream()
+	require.Nil(t, err)
+
+	_, err = stream.Do(NewBeginRequest()).Get()
+	require.Nil(t, err)
+
+	tuple := []interface{}{uint(1021), "hello", "world"}
+	_, err = stream.Do(NewInsertRequest(spaceNo).Tuple(tuple)).Get()
+	require.Nil(t, err)
+	defer test_helpers.DeleteRecordByKey(t, conn, spaceNo, indexNo, []interface{}{uint(1021)})
+
+	err = stream.Close()
+	require.Nil(t, err)
+
+	// The transaction is rolled back.
+	resp, err := conn.Select(spaceNo, indexNo, 0, 1, IterEq,
+		[]interface{}{uint(1021)})
+	require.Nil(t, err)
+	require.Len(t, resp.Data, 0)
+
+	_, err = stream.Do(NewPingRequest()).Get()
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "the stream is closed")
+
+	err = stream.Close()
+	require.Nil(t, err)
+}
+
+func TestStream_InTransaction(t *testing.T) {
+	test_helpers.SkipIfStreamsUnsupported(t)
+
+	conn := test_helpers.ConnectWithValidation(t, server, opts)
+	defer conn.Close()
+
+	stream, err := conn.NewStream()
+	require.Nil(t, err)
+	require.False(t, stream.InTransaction())
+
+	_, err = stream.Do(NewCommitRequest()).Get()
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "the stream transaction is not started")
+	_, err = stream.Do(NewRollbackRequest()).Get()
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "the stream transaction is not started")
+
+	_, err = stream.Do(NewBeginRequest()).Get()
+	require.Nil(t, err)
+	require.True(t, stream.InTransaction())
+
+	_, err = stream.Do(NewBeginRequest()).Get()
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "the stream transaction is already started")
+	require.True(t, stream.InTransaction())
+
+	_, err = stream.Do(NewCommitRequest()).Get()
+	require.Nil(t, err)
+	require.False(t, stream.InTransaction())
+}
+
 func TestStream_Rollback(t *testing.T) {
 	var req Request
 	var resp *Response
@@ -3553,6 +4716,35 @@ func TestConnection_NewWatcher(t *testing.T) {
 	}
 }
 
+func TestWatchEvent_DecodeValue(t *testing.T) {
+	type config struct {
+		Name  string `msgpack:"name"`
+		Count int    `msgpack:"count"`
+	}
+
+	event := WatchEvent{
+		Key: "TestWatchEvent_DecodeValue",
+		Value: map[interface{}]interface{}{
+			"name":  "foo",
+			"count": 42,
+		},
+	}
+
+	var cfg config
+	err := event.DecodeValue(&cfg)
+	require.Nil(t, err)
+	require.Equal(t, config{Name: "foo", Count: 42}, cfg)
+}
+
+func TestWatchEvent_DecodeValue_nil(t *testing.T) {
+	event := WatchEvent{Key: "TestWatchEvent_DecodeValue_nil"}
+
+	var value *string
+	err := event.DecodeValue(&value)
+	require.Nil(t, err)
+	require.Nil(t, value)
+}
+
 func TestConnection_NewWatcher_noWatchersFeature(t *testing.T) {
 	const key = "TestConnection_NewWatcher_noWatchersFeature"
 	connOpts := opts.Clone()
@@ -3622,6 +4814,440 @@ func TestConnection_NewWatcher_reconnect(t *testing.T) {
 	}
 }
 
+func TestConnection_SchemaVersion(t *testing.T) {
+	conn := test_helpers.ConnectWithValidation(t, server, opts)
+	defer conn.Close()
+
+	resp, err := conn.Ping()
+	require.Nil(t, err)
+	require.NotEqual(t, uint64(0), resp.SchemaVersion)
+	require.Equal(t, resp.SchemaVersion, conn.SchemaVersion())
+}
+
+func TestConnection_OnSchemaChange(t *testing.T) {
+	versions := make(chan uint64, 10)
+	connOpts := opts.Clone()
+	connOpts.OnSchemaChange = func(conn *Connection, version uint64) {
+		versions <- version
+	}
+	conn := test_helpers.ConnectWithValidation(t, server, connOpts)
+	defer conn.Close()
+
+	_, err := conn.Ping()
+	require.Nil(t, err)
+	initial := conn.SchemaVersion()
+
+	_, err = conn.Eval("box.schema.space.create('TestConnection_OnSchemaChange')",
+		[]interface{}{})
+	require.Nil(t, err)
+	_, err = conn.Eval("box.space.TestConnection_OnSchemaChange:drop()",
+		[]interface{}{})
+	require.Nil(t, err)
+	_, err = conn.Ping()
+	require.Nil(t, err)
+
+	select {
+	case version := <-versions:
+		require.Greater(t, version, initial)
+	case <-time.After(time.Second):
+		t.Fatalf("OnSchemaChange is not called")
+	}
+	require.Greater(t, conn.SchemaVersion(), initial)
+}
+
+func TestConnection_OnRequestHooks(t *testing.T) {
+	type requestEnd struct {
+		label string
+		code  int32
+		dur   time.Duration
+		err   error
+	}
+	starts := make(chan int32, 10)
+	ends := make(chan requestEnd, 10)
+
+	connOpts := opts.Clone()
+	connOpts.SkipSchema = true
+	connOpts.Label = "metrics"
+	// Internal pings are reported too, so only eval requests are recorded.
+	connOpts.OnRequestStart = func(label string, code int32) {
+		if code != EvalRequestCode {
+			return
+		}
+		select {
+		case starts <- code:
+		default:
+		}
+	}
+	connOpts.OnRequestEnd = func(label string, code int32, dur time.Duration, err error) {
+		if code != EvalRequestCode {
+			return
+		}
+		select {
+		case ends <- requestEnd{label, code, dur, err}:
+		default:
+		}
+	}
+	conn := test_helpers.ConnectWithValidation(t, server, connOpts)
+	defer conn.Close()
+
+	require.Equal(t, "metrics", conn.Label())
+
+	_, err := conn.Do(NewEvalRequest("return")).Get()
+	require.Nil(t, err)
+	require.Equal(t, int32(EvalRequestCode), <-starts)
+	end := <-ends
+	require.Equal(t, "metrics", end.label)
+	require.Equal(t, int32(EvalRequestCode), end.code)
+	require.Greater(t, end.dur, time.Duration(0))
+	require.Nil(t, end.err)
+
+	_, err = conn.Do(NewEvalRequest("error('hook')")).Get()
+	require.NotNil(t, err)
+	require.Equal(t, int32(EvalRequestCode), <-starts)
+	end = <-ends
+	require.Equal(t, int32(EvalRequestCode), end.code)
+	require.NotNil(t, end.err)
+	require.IsType(t, Error{}, end.err)
+}
+
+func TestConnection_Reconnect(t *testing.T) {
+	conn := test_helpers.ConnectWithValidation(t, server, opts)
+	defer conn.Close()
+
+	localAddr := conn.LocalAddr()
+	fut := conn.Do(NewEvalRequest("require('fiber').sleep(0.3)"))
+
+	err := conn.Reconnect()
+	require.Nil(t, err)
+	require.True(t, conn.ConnectedNow())
+	require.NotEqual(t, localAddr, conn.LocalAddr())
+
+	_, err = fut.Get()
+	require.NotNil(t, err)
+	clientErr, ok := err.(ClientError)
+	require.True(t, ok, "unexpected error: %v", err)
+	require.Equal(t, uint32(ErrConnectionNotReady), clientErr.Code)
+	require.True(t, clientErr.Temporary())
+
+	_, err = conn.Ping()
+	require.Nil(t, err)
+}
+
+func TestConnection_Reconnect_closed(t *testing.T) {
+	conn := test_helpers.ConnectWithValidation(t, server, opts)
+	conn.Close()
+
+	err := conn.Reconnect()
+	require.NotNil(t, err)
+	require.True(t, conn.ClosedNow())
+}
+
+func TestConnection_Info(t *testing.T) {
+	conn := test_helpers.ConnectWithValidation(t, server, opts)
+	defer conn.Close()
+
+	info, err := conn.Info()
+	require.Nil(t, err)
+	require.NotEmpty(t, info.Version)
+	require.NotEmpty(t, info.UUID)
+	require.Equal(t, "running", info.Status)
+	require.False(t, info.RO)
+	require.Contains(t, info.VClock, info.Id)
+	require.Contains(t, info.Replication, info.Id)
+	require.Equal(t, info.UUID, info.Replication[info.Id].UUID)
+}
+
+func TestConnection_MaxRequestSize(t *testing.T) {
+	limitOpts := opts.Clone()
+	limitOpts.MaxRequestSize = 1024
+	conn := test_helpers.ConnectWithValidation(t, server, limitOpts)
+	defer conn.Close()
+
+	tuple := []interface{}{uint(1030), strings.Repeat("a", 2048), "world"}
+	_, err := conn.Replace(spaceNo, tuple)
+	require.NotNil(t, err)
+	require.True(t, IsError(err, ErrRequestTooLarge), "unexpected error: %v", err)
+
+	_, err = conn.Ping()
+	require.Nil(t, err)
+}
+
+func TestConnection_MaxInFlight(t *testing.T) {
+	limitOpts := opts.Clone()
+	limitOpts.MaxInFlight = 1
+	conn := test_helpers.ConnectWithValidation(t, server, limitOpts)
+	defer conn.Close()
+
+	slow := conn.EvalAsync("require('fiber').sleep(0.3)", []interface{}{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := conn.Do(NewPingRequest().Context(ctx)).Get()
+	require.EqualError(t, err, "context is done")
+
+	start := time.Now()
+	_, err = conn.Ping()
+	require.Nil(t, err)
+	require.GreaterOrEqual(t, int64(time.Since(start)), int64(100*time.Millisecond))
+
+	_, err = slow.Get()
+	require.Nil(t, err)
+}
+
+func TestConnection_MaxInFlight_canceled(t *testing.T) {
+	limitOpts := opts.Clone()
+	limitOpts.MaxInFlight = 2
+	conn := test_helpers.ConnectWithValidation(t, server, limitOpts)
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for i := 0; i < 5; i++ {
+		_, err := conn.Do(NewPingRequest().Context(ctx)).Get()
+		require.EqualError(t, err, "context is done")
+	}
+
+	_, err := conn.Ping()
+	require.Nil(t, err)
+}
+
+type unexpectedResultLogger struct {
+	count int32
+}
+
+func (l *unexpectedResultLogger) Report(event ConnLogKind, conn *Connection,
+	v ...interface{}) {
+	if event == LogUnexpectedResultId {
+		atomic.AddInt32(&l.count, 1)
+	}
+}
+
+func TestFuture_Discard(t *testing.T) {
+	logger := &unexpectedResultLogger{}
+	discardOpts := opts.Clone()
+	discardOpts.Logger = logger
+	conn := test_helpers.ConnectWithValidation(t, server, discardOpts)
+	defer conn.Close()
+
+	fut := conn.EvalAsync("require('fiber').sleep(0.2)", []interface{}{})
+	fut.Discard()
+
+	_, err := fut.Get()
+	require.EqualError(t, err, "the future is discarded")
+
+	// Wait for the response of the discarded request.
+	time.Sleep(300 * time.Millisecond)
+	_, err = conn.Ping()
+	require.Nil(t, err)
+	require.Equal(t, int32(0), atomic.LoadInt32(&logger.count))
+
+	// It does nothing for a finished future.
+	fut = conn.Do(NewPingRequest())
+	_, err = fut.Get()
+	require.Nil(t, err)
+	fut.Discard()
+	_, err = fut.Get()
+	require.Nil(t, err)
+}
+
+func TestConnection_CircuitBreaker(t *testing.T) {
+	breakerOpts := opts.Clone()
+	breakerOpts.Timeout = 100 * time.Millisecond
+	breakerOpts.CircuitBreaker = CircuitBreaker{
+		Threshold:    2,
+		OpenDuration: 200 * time.Millisecond,
+	}
+	conn := test_helpers.ConnectWithValidation(t, server, breakerOpts)
+	defer conn.Close()
+
+	require.False(t, conn.CircuitOpen())
+	for i := 0; i < 2; i++ {
+		_, err := conn.Eval("require('fiber').sleep(0.5)", []interface{}{})
+		require.True(t, IsError(err, ErrTimeouted), "unexpected error: %v", err)
+	}
+	require.True(t, conn.CircuitOpen())
+
+	_, err := conn.Ping()
+	require.True(t, IsError(err, ErrCircuitOpen), "unexpected error: %v", err)
+
+	time.Sleep(breakerOpts.CircuitBreaker.OpenDuration)
+	require.False(t, conn.CircuitOpen())
+
+	_, err = conn.Ping()
+	require.Nil(t, err)
+	require.False(t, conn.CircuitOpen())
+}
+
+func TestConnection_CircuitBreakerProbeReleased(t *testing.T) {
+	breakerOpts := opts.Clone()
+	breakerOpts.Timeout = 100 * time.Millisecond
+	breakerOpts.CircuitBreaker = CircuitBreaker{
+		Threshold:    1,
+		OpenDuration: 200 * time.Millisecond,
+	}
+	conn := test_helpers.ConnectWithValidation(t, server, breakerOpts)
+	defer conn.Close()
+
+	openCircuit := func() {
+		_, err := conn.Eval("require('fiber').sleep(0.5)", []interface{}{})
+		require.True(t, IsError(err, ErrTimeouted), "unexpected error: %v", err)
+		require.True(t, conn.CircuitOpen())
+		time.Sleep(breakerOpts.CircuitBreaker.OpenDuration)
+	}
+
+	// A probe with an encoding error.
+	openCircuit()
+	_, err := conn.Call("func", []interface{}{make(chan int)})
+	require.NotNil(t, err)
+	require.False(t, IsError(err, ErrCircuitOpen), "unexpected error: %v", err)
+
+	_, err = conn.Ping()
+	require.Nil(t, err)
+	require.False(t, conn.CircuitOpen())
+
+	// A probe with a canceled context.
+	openCircuit()
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	req := NewEvalRequest("require('fiber').sleep(0.05)").Context(ctx)
+	_, err = conn.Do(req).Get()
+	require.EqualError(t, err, "context is done")
+
+	_, err = conn.Ping()
+	require.Nil(t, err)
+	require.False(t, conn.CircuitOpen())
+}
+
+func TestConnection_CircuitBreakerDisabled(t *testing.T) {
+	timeoutOpts := opts.Clone()
+	timeoutOpts.Timeout = 100 * time.Millisecond
+	conn := test_helpers.ConnectWithValidation(t, server, timeoutOpts)
+	defer conn.Close()
+
+	for i := 0; i < 3; i++ {
+		_, err := conn.Eval("require('fiber').sleep(0.5)", []interface{}{})
+		require.True(t, IsError(err, ErrTimeouted), "unexpected error: %v", err)
+	}
+	require.False(t, conn.CircuitOpen())
+
+	_, err := conn.Ping()
+	require.Nil(t, err)
+}
+
+func TestConnection_SessionId(t *testing.T) {
+	conn := test_helpers.ConnectWithValidation(t, server, opts)
+	defer conn.Close()
+
+	var expected []uint64
+	err := conn.EvalTyped("return box.session.id()", []interface{}{}, &expected)
+	require.Nil(t, err)
+	require.Len(t, expected, 1)
+
+	id, err := conn.SessionId()
+	require.Nil(t, err)
+	require.Equal(t, expected[0], id)
+
+	cached, err := conn.SessionId()
+	require.Nil(t, err)
+	require.Equal(t, id, cached)
+
+	err = conn.Reconnect()
+	require.Nil(t, err)
+
+	newId, err := conn.SessionId()
+	require.Nil(t, err)
+	require.NotEqual(t, id, newId)
+}
+
+type blackholeConn struct {
+	Conn
+	drop *int32
+}
+
+func (c blackholeConn) Write(b []byte) (int, error) {
+	if atomic.LoadInt32(c.drop) != 0 {
+		return len(b), nil
+	}
+	return c.Conn.Write(b)
+}
+
+type blackholeDialer struct {
+	drop *int32
+}
+
+func (d blackholeDialer) Dial(address string, opts DialOpts) (Conn, error) {
+	conn, err := TtDialer{}.Dial(address, opts)
+	if err != nil {
+		return nil, err
+	}
+	return blackholeConn{conn, d.drop}, nil
+}
+
+func TestConnection_Keepalive(t *testing.T) {
+	var drop int32
+	connOpts := opts.Clone()
+	connOpts.Timeout = 0
+	connOpts.Keepalive = 200 * time.Millisecond
+	connOpts.Dialer = blackholeDialer{&drop}
+	conn := test_helpers.ConnectWithValidation(t, server, connOpts)
+	defer conn.Close()
+
+	time.Sleep(3 * connOpts.Keepalive)
+	require.True(t, conn.ConnectedNow())
+
+	atomic.StoreInt32(&drop, 1)
+	deadline := time.Now().Add(10 * connOpts.Keepalive)
+	for !conn.ClosedNow() && time.Now().Before(deadline) {
+		time.Sleep(connOpts.Keepalive / 4)
+	}
+	require.True(t, conn.ClosedNow(), "a dead connection is not detected")
+}
+
+func TestConnection_KeepaliveBypassesLimits(t *testing.T) {
+	connOpts := opts.Clone()
+	connOpts.Timeout = 0
+	connOpts.Keepalive = 200 * time.Millisecond
+	connOpts.MaxInFlight = 1
+	conn := test_helpers.ConnectWithValidation(t, server, connOpts)
+	defer conn.Close()
+
+	conn.Use(func(req Request, next func(Request) *Future) *Future {
+		if req.Code() == PingRequestCode {
+			fut := NewFuture()
+			fut.SetError(fmt.Errorf("intercepted"))
+			return fut
+		}
+		return next(req)
+	})
+
+	// The connection is idle while the request is executed, so keepalive
+	// pings are sent with the only in-flight slot taken.
+	_, err := conn.Eval("require('fiber').sleep(1)", []interface{}{})
+	require.Nil(t, err)
+	require.True(t, conn.ConnectedNow())
+}
+
+func TestConnection_WatchOnce(t *testing.T) {
+	test_helpers.SkipIfWatchOnceUnsupported(t)
+
+	const key = "TestConnection_WatchOnce"
+	const value = "bar"
+
+	conn := test_helpers.ConnectWithValidation(t, server, opts)
+	defer conn.Close()
+
+	resp, err := conn.WatchOnce(key)
+	require.Nil(t, err)
+	require.Equal(t, 0, len(resp.Data))
+
+	_, err = conn.Do(NewBroadcastRequest(key).Value(value)).Get()
+	require.Nil(t, err)
+
+	resp, err = conn.WatchOnce(key)
+	require.Nil(t, err)
+	require.Equal(t, []interface{}{value}, resp.Data)
+}
+
 func TestBroadcastRequest(t *testing.T) {
 	test_helpers.SkipIfWatchersUnsupported(t)
 
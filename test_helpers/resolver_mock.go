@@ -0,0 +1,43 @@
+package test_helpers
+
+import (
+	"fmt"
+
+	"github.com/tarantool/go-tarantool"
+)
+
+// MapSchemaResolver is a tarantool.SchemaResolver which resolves space and
+// index names with maps. It allows to test request bodies without
+// a connection to Tarantool.
+type MapSchemaResolver struct {
+	spaces  map[string]uint32
+	indexes map[string]uint32
+}
+
+// NewMapSchemaResolver creates a new MapSchemaResolver with maps of space
+// names to space ids and index names to index ids. Numeric spaces and indexes
+// are used as is.
+func NewMapSchemaResolver(spaces, indexes map[string]uint32) *MapSchemaResolver {
+	return &MapSchemaResolver{
+		spaces:  spaces,
+		indexes: indexes,
+	}
+}
+
+// ResolveSpaceIndex resolves space and index numbers with the maps.
+func (r *MapSchemaResolver) ResolveSpaceIndex(s interface{},
+	i interface{}) (spaceNo, indexNo uint32, err error) {
+	if name, ok := s.(string); ok {
+		if s, ok = r.spaces[name]; !ok {
+			return 0, 0, fmt.Errorf("there is no space with name %s", name)
+		}
+	}
+	if name, ok := i.(string); ok {
+		if i, ok = r.indexes[name]; !ok {
+			return 0, 0, fmt.Errorf("there is no index with name %s", name)
+		}
+	}
+
+	var schema *tarantool.Schema
+	return schema.ResolveSpaceIndex(s, i)
+}
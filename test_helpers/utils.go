@@ -192,6 +192,30 @@ func SkipIfPaginationUnsupported(t *testing.T) {
 	SkipIfFeatureUnsupported(t, "pagination", 2, 11, 0)
 }
 
+// SkipIfWatchOnceUnsupported skips test run if Tarantool without
+// IPROTO_WATCH_ONCE support is used.
+func SkipIfWatchOnceUnsupported(t *testing.T) {
+	t.Helper()
+
+	SkipIfFeatureUnsupported(t, "watch once", 3, 0, 0)
+}
+
+// SkipIfSpaceAndIndexNamesUnsupported skips test run if Tarantool without
+// space and index names in requests support is used.
+func SkipIfSpaceAndIndexNamesUnsupported(t *testing.T) {
+	t.Helper()
+
+	SkipIfFeatureUnsupported(t, "space and index names", 3, 0, 0)
+}
+
+// SkipIfSpaceAndIndexNamesSupported skips test run if Tarantool with space
+// and index names in requests support is used.
+func SkipIfSpaceAndIndexNamesSupported(t *testing.T) {
+	t.Helper()
+
+	SkipIfFeatureSupported(t, "space and index names", 3, 0, 0)
+}
+
 // CheckEqualBoxErrors checks equivalence of tarantool.BoxError objects.
 //
 // Tarantool errors are not comparable by nature:
@@ -0,0 +1,80 @@
+package tarantool
+
+import (
+	"reflect"
+)
+
+// TupleDecoder is implemented by a type which decodes a tuple without
+// reflection. If a pointer to an item type of a result slice implements it,
+// typed requests call DecodeTuple for each tuple instead of the reflection
+// based decoding of the msgpack library:
+//
+//	type User struct {
+//		Id   uint
+//		Name string
+//	}
+//
+//	func (u *User) DecodeTuple(d *msgpack.Decoder) error {
+//		if _, err := d.DecodeArrayLen(); err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+//	var users []User
+//	err := conn.SelectTyped(space, index, 0, 10, IterAll, key, &users)
+//
+// The decoder is positioned at the tuple and DecodeTuple must decode the
+// whole tuple. It is used for a slice of pointers to the type too.
+type TupleDecoder interface {
+	DecodeTuple(d *decoder) error
+}
+
+var tupleDecoderType = reflect.TypeOf((*TupleDecoder)(nil)).Elem()
+
+// decodeTuples decodes an array of tuples into the result with DecodeTuple
+// calls. It returns false if the result is not a pointer to a slice of
+// TupleDecoder values.
+func decodeTuples(d *decoder, result interface{}) (bool, error) {
+	val := reflect.ValueOf(result)
+	if val.Kind() != reflect.Ptr || val.IsNil() ||
+		val.Elem().Kind() != reflect.Slice {
+		return false, nil
+	}
+	val = val.Elem()
+
+	elemType := val.Type().Elem()
+	isPtr := elemType.Kind() == reflect.Ptr
+	if !isPtr {
+		elemType = reflect.PtrTo(elemType)
+	}
+	if !elemType.Implements(tupleDecoderType) {
+		return false, nil
+	}
+
+	l, err := d.DecodeArrayLen()
+	if err != nil {
+		return true, err
+	}
+	if l < 0 {
+		val.Set(reflect.Zero(val.Type()))
+		return true, nil
+	}
+
+	slice := reflect.MakeSlice(val.Type(), l, l)
+	for i := 0; i < l; i++ {
+		var tuple TupleDecoder
+		if isPtr {
+			item := reflect.New(elemType.Elem())
+			slice.Index(i).Set(item)
+			tuple = item.Interface().(TupleDecoder)
+		} else {
+			tuple = slice.Index(i).Addr().Interface().(TupleDecoder)
+		}
+		if err := tuple.DecodeTuple(d); err != nil {
+			return true, err
+		}
+	}
+	val.Set(slice)
+	return true, nil
+}
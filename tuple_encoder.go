@@ -0,0 +1,28 @@
+package tarantool
+
+// TupleEncoder is implemented by a type which encodes a tuple without
+// reflection. Insert, Replace and Upsert requests call EncodeTuple for
+// a tuple which implements it instead of the msgpack library encoding:
+//
+//	func (u *User) EncodeTuple(e *msgpack.Encoder) error {
+//		if err := e.EncodeArrayLen(2); err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+//	_, err := conn.Insert(space, &user)
+//
+// EncodeTuple must encode the whole tuple as an array.
+type TupleEncoder interface {
+	EncodeTuple(e *encoder) error
+}
+
+// encodeTuple encodes the tuple with EncodeTuple if it implements
+// TupleEncoder or with the msgpack library otherwise.
+func encodeTuple(enc *encoder, tuple interface{}) error {
+	if te, ok := tuple.(TupleEncoder); ok {
+		return te.EncodeTuple(enc)
+	}
+	return enc.Encode(tuple)
+}
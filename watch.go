@@ -1,6 +1,7 @@
 package tarantool
 
 import (
+	"bytes"
 	"context"
 )
 
@@ -52,6 +53,40 @@ func (req *BroadcastRequest) Async() bool {
 	return req.call.Async()
 }
 
+// WatchOnceRequest helps to read the current value of a specified key
+// defined on the server without subscribing to its updates.
+//
+// Requires Tarantool >= 3.0.
+type WatchOnceRequest struct {
+	baseRequest
+	key string
+}
+
+// NewWatchOnceRequest returns a new watch once request for a specified key.
+func NewWatchOnceRequest(key string) *WatchOnceRequest {
+	req := new(WatchOnceRequest)
+	req.requestCode = WatchOnceRequestCode
+	req.key = key
+	return req
+}
+
+// Body fills an encoder with the watch once request body.
+func (req *WatchOnceRequest) Body(res SchemaResolver, enc *encoder) error {
+	if err := enc.EncodeMapLen(1); err != nil {
+		return err
+	}
+	if err := encodeUint(enc, KeyEvent); err != nil {
+		return err
+	}
+	return enc.EncodeString(req.key)
+}
+
+// Context sets a passed context to the request.
+func (req *WatchOnceRequest) Context(ctx context.Context) *WatchOnceRequest {
+	req.ctx = ctx
+	return req
+}
+
 // watchRequest subscribes to the updates of a specified key defined on the
 // server. After receiving the notification, you should send a new
 // watchRequest to acknowledge the notification.
@@ -128,6 +163,18 @@ type WatchEvent struct {
 	Value interface{} // A value.
 }
 
+// DecodeValue decodes the event value into the result. The result must be
+// a pointer to a value which could be decoded from the value msgpack
+// representation, for example, a pointer to a struct that implements
+// the msgpack.CustomDecoder interface.
+func (event WatchEvent) DecodeValue(result interface{}) error {
+	var buf bytes.Buffer
+	if err := newEncoder(&buf).Encode(event.Value); err != nil {
+		return err
+	}
+	return newDecoder(&buf).Decode(result)
+}
+
 // Watcher is a subscription to broadcast events.
 type Watcher interface {
 	// Unregister unregisters the watcher.